@@ -0,0 +1,289 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"uxbench/cli/format"
+	"uxbench/schema"
+)
+
+// MergeReports combines repeated runs of one product/task into a single report
+// whose metrics are the mean of the runs, mirroring the recorder's multi-run
+// averaging: counts are rounded to whole numbers, ratios and Fitts values to two
+// decimals, and optional fields are averaged over the runs that have them.
+// Descriptive details (idle gaps, click details, human signals) come from the
+// last run, except that the hardest targets and free-text fields are merged.
+func MergeReports(runs []*schema.BenchmarkReport) (*schema.BenchmarkReport, error) {
+	if len(runs) == 0 {
+		return nil, fmt.Errorf("no reports to merge")
+	}
+	first := runs[0].Metadata
+	for _, r := range runs[1:] {
+		if r.Metadata.Product != first.Product || r.Metadata.Task != first.Task {
+			return nil, fmt.Errorf("cannot merge %s — %s with %s — %s: product and task must match",
+				first.Product, first.Task, r.Metadata.Product, r.Metadata.Task)
+		}
+	}
+
+	last := runs[len(runs)-1]
+	merged := &schema.BenchmarkReport{
+		SchemaVersion: last.SchemaVersion,
+		Source:        last.Source,
+		Metadata:      last.Metadata,
+		Metrics:       AverageMetrics(runs),
+		HumanSignals:  last.HumanSignals,
+	}
+
+	md := &merged.Metadata
+	md.RecordingName = fmt.Sprintf("%s %s (average of %d runs)", first.Product, first.Task, len(runs))
+	md.DurationMS = meanInt(runs, func(r *schema.BenchmarkReport) int { return r.Metadata.DurationMS })
+	md.NavigationCount = meanInt(runs, func(r *schema.BenchmarkReport) int { return r.Metadata.NavigationCount })
+	md.NavigationGapMS = meanInt(runs, func(r *schema.BenchmarkReport) int { return r.Metadata.NavigationGapMS })
+	seen := make(map[string]bool)
+	md.URLsVisited = nil
+	for _, r := range runs {
+		if r.Metadata.Timestamp.After(md.Timestamp) {
+			md.Timestamp = r.Metadata.Timestamp
+		}
+		for _, u := range r.Metadata.URLsVisited {
+			if !seen[u] {
+				seen[u] = true
+				md.URLsVisited = append(md.URLsVisited, u)
+			}
+		}
+	}
+
+	return merged, nil
+}
+
+// AverageMetrics returns the mean of the runs' metrics (see MergeReports).
+func AverageMetrics(runs []*schema.BenchmarkReport) schema.BenchmarkMetrics {
+	metrics := func(f func(m schema.BenchmarkMetrics) int) int {
+		return meanInt(runs, func(r *schema.BenchmarkReport) int { return f(r.Metrics) })
+	}
+	float := func(f func(m schema.BenchmarkMetrics) float64, round func(float64) float64) float64 {
+		sum := 0.0
+		for _, r := range runs {
+			sum += f(r.Metrics)
+		}
+		return round(sum / float64(len(runs)))
+	}
+
+	out := runs[len(runs)-1].Metrics
+
+	out.ClickCount.Total = metrics(func(m schema.BenchmarkMetrics) int { return m.ClickCount.Total })
+	out.ClickCount.Productive = metrics(func(m schema.BenchmarkMetrics) int { return m.ClickCount.Productive })
+	out.ClickCount.Ceremonial = metrics(func(m schema.BenchmarkMetrics) int { return m.ClickCount.Ceremonial })
+	out.ClickCount.Wasted = metrics(func(m schema.BenchmarkMetrics) int { return m.ClickCount.Wasted })
+
+	out.TimeOnTask.TotalMS = metrics(func(m schema.BenchmarkMetrics) int { return m.TimeOnTask.TotalMS })
+	out.TimeOnTask.ActiveMS = meanOptInt(runs, func(m schema.BenchmarkMetrics) *int { return m.TimeOnTask.ActiveMS })
+	out.TimeOnTask.IdleMS = meanOptInt(runs, func(m schema.BenchmarkMetrics) *int { return m.TimeOnTask.IdleMS })
+
+	out.CompositeScore = float(func(m schema.BenchmarkMetrics) float64 { return m.CompositeScore }, round2)
+
+	out.Fitts.CumulativeID = float(func(m schema.BenchmarkMetrics) float64 { return m.Fitts.CumulativeID }, round2)
+	out.Fitts.AverageID = float(func(m schema.BenchmarkMetrics) float64 { return m.Fitts.AverageID }, round2)
+	out.Fitts.MaxID = float(func(m schema.BenchmarkMetrics) float64 { return m.Fitts.MaxID }, round2)
+	out.Fitts.AveragePathEfficiency = meanOptFloat(runs, func(m schema.BenchmarkMetrics) *float64 { return m.Fitts.AveragePathEfficiency })
+	out.Fitts.TotalOvershoots = meanOptInt(runs, func(m schema.BenchmarkMetrics) *int { return m.Fitts.TotalOvershoots })
+	out.Fitts.Throughput = meanThroughput(runs)
+
+	// Hardest targets across all runs; the max-ID element from the run that hit it
+	var hardest []schema.FittsTarget
+	worst := runs[0]
+	for _, r := range runs {
+		hardest = append(hardest, r.Metrics.Fitts.Top3Hardest...)
+		if r.Metrics.Fitts.MaxID > worst.Metrics.Fitts.MaxID {
+			worst = r
+		}
+	}
+	sort.SliceStable(hardest, func(i, j int) bool { return hardest[i].ID > hardest[j].ID })
+	if len(hardest) > 3 {
+		hardest = hardest[:3]
+	}
+	out.Fitts.Top3Hardest = hardest
+	out.Fitts.MaxIDElement = worst.Metrics.Fitts.MaxIDElement
+	out.Fitts.MaxIDDistancePx = worst.Metrics.Fitts.MaxIDDistancePx
+	out.Fitts.MaxIDTargetSize = worst.Metrics.Fitts.MaxIDTargetSize
+
+	out.ContextSwitches.Total = metrics(func(m schema.BenchmarkMetrics) int { return m.ContextSwitches.Total })
+	out.ContextSwitches.Ratio = float(func(m schema.BenchmarkMetrics) float64 { return m.ContextSwitches.Ratio }, round2)
+	out.ContextSwitches.LongestKeyboardStreak = meanOptInt(runs, func(m schema.BenchmarkMetrics) *int { return m.ContextSwitches.LongestKeyboardStreak })
+	out.ContextSwitches.LongestMouseStreak = meanOptInt(runs, func(m schema.BenchmarkMetrics) *int { return m.ContextSwitches.LongestMouseStreak })
+
+	out.ShortcutCoverage.ShortcutsUsed = metrics(func(m schema.BenchmarkMetrics) int { return m.ShortcutCoverage.ShortcutsUsed })
+
+	out.TypingRatio.FreeTextInputs = metrics(func(m schema.BenchmarkMetrics) int { return m.TypingRatio.FreeTextInputs })
+	out.TypingRatio.ConstrainedInputs = metrics(func(m schema.BenchmarkMetrics) int { return m.TypingRatio.ConstrainedInputs })
+	out.TypingRatio.Ratio = float(func(m schema.BenchmarkMetrics) float64 { return m.TypingRatio.Ratio }, round2)
+	fields := make(map[string]bool)
+	out.TypingRatio.FreeTextFields = nil
+	for _, r := range runs {
+		for _, f := range r.Metrics.TypingRatio.FreeTextFields {
+			if !fields[f] {
+				fields[f] = true
+				out.TypingRatio.FreeTextFields = append(out.TypingRatio.FreeTextFields, f)
+			}
+		}
+	}
+
+	out.ScanningDistance.CumulativePx = float(func(m schema.BenchmarkMetrics) float64 { return m.ScanningDistance.CumulativePx }, math.Round)
+	out.ScanningDistance.AveragePx = float(func(m schema.BenchmarkMetrics) float64 { return m.ScanningDistance.AveragePx }, math.Round)
+	out.ScanningDistance.MaxSinglePx = float(func(m schema.BenchmarkMetrics) float64 { return m.ScanningDistance.MaxSinglePx }, math.Round)
+
+	out.ScrollDistance.TotalPx = float(func(m schema.BenchmarkMetrics) float64 { return m.ScrollDistance.TotalPx }, math.Round)
+	out.ScrollDistance.PageScrollPx = meanOptFloat(runs, func(m schema.BenchmarkMetrics) *float64 { return m.ScrollDistance.PageScrollPx })
+	out.ScrollDistance.ContainerScrollPx = meanOptFloat(runs, func(m schema.BenchmarkMetrics) *float64 { return m.ScrollDistance.ContainerScrollPx })
+	out.ScrollDistance.ScrollEvents = meanOptInt(runs, func(m schema.BenchmarkMetrics) *int { return m.ScrollDistance.ScrollEvents })
+
+	return out
+}
+
+// Outlier is one run's value for a metric that is out of line with the other runs.
+type Outlier struct {
+	Run          int // index into the runs passed to FindOutliers
+	Metric       format.MetricDef
+	Value        float64
+	OthersMean   float64
+	OthersStdDev float64
+}
+
+// Outlier thresholds: a value is flagged when it is more than OutlierSigma
+// standard deviations from the mean of the other runs and differs from that
+// mean by at least OutlierMinDeviation of it (so near-identical runs with a
+// standard deviation of ~0 don't flag tiny differences).
+const (
+	OutlierSigma        = 2.0
+	OutlierMinDeviation = 0.10
+)
+
+// FindOutliers flags, per registry metric, runs whose value is out of line with
+// the rest. Each run is compared against the others (leave-one-out), since with
+// the usual 3-5 runs a single extreme value inflates the overall deviation too
+// much to ever be 2σ from the overall mean. Needs at least 3 runs; missing
+// values are ignored.
+func FindOutliers(runs []*schema.BenchmarkReport) []Outlier {
+	var outliers []Outlier
+	for _, def := range format.MetricRegistry {
+		for i, r := range runs {
+			val := def.Value(r)
+			if format.IsMissing(val) {
+				continue
+			}
+			var others []float64
+			for j, o := range runs {
+				if v := def.Value(o); j != i && !format.IsMissing(v) {
+					others = append(others, v)
+				}
+			}
+			if len(others) < 2 {
+				continue
+			}
+			mean, sd := meanStdDev(others)
+			dev := math.Abs(val - mean)
+			if dev > OutlierSigma*sd && dev >= OutlierMinDeviation*math.Abs(mean) && dev > 0 {
+				outliers = append(outliers, Outlier{Run: i, Metric: def, Value: val, OthersMean: mean, OthersStdDev: sd})
+			}
+		}
+	}
+	return outliers
+}
+
+// DropOutliers returns the runs with no flagged metric, and the indices of those removed.
+func DropOutliers(runs []*schema.BenchmarkReport, outliers []Outlier) ([]*schema.BenchmarkReport, []int) {
+	flagged := make(map[int]bool)
+	for _, o := range outliers {
+		flagged[o.Run] = true
+	}
+	var kept []*schema.BenchmarkReport
+	var dropped []int
+	for i, r := range runs {
+		if flagged[i] {
+			dropped = append(dropped, i)
+		} else {
+			kept = append(kept, r)
+		}
+	}
+	return kept, dropped
+}
+
+// meanStdDev returns the mean and population standard deviation of vals.
+func meanStdDev(vals []float64) (float64, float64) {
+	sum := 0.0
+	for _, v := range vals {
+		sum += v
+	}
+	mean := sum / float64(len(vals))
+	sq := 0.0
+	for _, v := range vals {
+		sq += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(sq / float64(len(vals)))
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func meanInt(runs []*schema.BenchmarkReport, f func(*schema.BenchmarkReport) int) int {
+	sum := 0
+	for _, r := range runs {
+		sum += f(r)
+	}
+	return int(math.Round(float64(sum) / float64(len(runs))))
+}
+
+// meanOptInt averages an optional count over the runs that have it; nil if none do.
+func meanOptInt(runs []*schema.BenchmarkReport, f func(schema.BenchmarkMetrics) *int) *int {
+	sum, n := 0, 0
+	for _, r := range runs {
+		if v := f(r.Metrics); v != nil {
+			sum += *v
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	mean := int(math.Round(float64(sum) / float64(n)))
+	return &mean
+}
+
+// meanOptFloat averages an optional value over the runs that have it; nil if none do.
+func meanOptFloat(runs []*schema.BenchmarkReport, f func(schema.BenchmarkMetrics) *float64) *float64 {
+	sum, n := 0.0, 0
+	for _, r := range runs {
+		if v := f(r.Metrics); v != nil {
+			sum += *v
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	mean := round2(sum / float64(n))
+	return &mean
+}
+
+func meanThroughput(runs []*schema.BenchmarkReport) *schema.FittsThroughput {
+	var sum schema.FittsThroughput
+	n := 0
+	for _, r := range runs {
+		if t := r.Metrics.Fitts.Throughput; t != nil {
+			sum.AMS += t.AMS
+			sum.BMsPerBit += t.BMsPerBit
+			sum.RSquared += t.RSquared
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	return &schema.FittsThroughput{
+		AMS:       round2(sum.AMS / float64(n)),
+		BMsPerBit: round2(sum.BMsPerBit / float64(n)),
+		RSquared:  round2(sum.RSquared / float64(n)),
+	}
+}
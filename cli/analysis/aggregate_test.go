@@ -0,0 +1,67 @@
+package analysis
+
+import (
+	"slices"
+	"testing"
+
+	"uxbench/schema"
+)
+
+// timedRuns returns one run per total time, identical in every other metric.
+func timedRuns(totals ...int) []*schema.BenchmarkReport {
+	runs := make([]*schema.BenchmarkReport, len(totals))
+	for i, ms := range totals {
+		r := &schema.BenchmarkReport{SchemaVersion: schema.CurrentSchemaVersion}
+		r.Metadata.Product = "Acme"
+		r.Metadata.Task = "Create customer"
+		r.Metrics.ClickCount.Total = 12
+		r.Metrics.ClickCount.Productive = 10
+		r.Metrics.TimeOnTask.TotalMS = ms
+		r.Metrics.CompositeScore = 70
+		runs[i] = r
+	}
+	return runs
+}
+
+func TestFindOutliersPlanted(t *testing.T) {
+	runs := timedRuns(30000, 31000, 29500, 95000, 30500)
+
+	outliers := FindOutliers(runs)
+	if len(outliers) != 1 {
+		t.Fatalf("FindOutliers = %+v, want exactly the planted value", outliers)
+	}
+	o := outliers[0]
+	if o.Run != 3 || o.Metric.Key != "time_on_task_ms" || o.Value != 95000 {
+		t.Errorf("flagged run %d, %s = %v; want run 3, time_on_task_ms = 95000", o.Run, o.Metric.Key, o.Value)
+	}
+	if o.OthersMean != 30250 {
+		t.Errorf("OthersMean = %v, want 30250", o.OthersMean)
+	}
+
+	kept, dropped := DropOutliers(runs, outliers)
+	if !slices.Equal(dropped, []int{3}) {
+		t.Errorf("dropped = %v, want [3]", dropped)
+	}
+	if want := []*schema.BenchmarkReport{runs[0], runs[1], runs[2], runs[4]}; !slices.Equal(kept, want) {
+		t.Errorf("kept %d runs, want runs 0, 1, 2 and 4 in order", len(kept))
+	}
+}
+
+func TestFindOutliersIgnoresNearIdenticalRuns(t *testing.T) {
+	// 30100 is far more than 2σ from the others (σ ≈ 7ms) but only 0.3% off
+	// their mean, under OutlierMinDeviation.
+	runs := timedRuns(30000, 30010, 29990, 30005, 30100)
+	if outliers := FindOutliers(runs); len(outliers) != 0 {
+		t.Errorf("FindOutliers = %+v, want none", outliers)
+	}
+	kept, dropped := DropOutliers(runs, nil)
+	if len(kept) != len(runs) || dropped != nil {
+		t.Errorf("DropOutliers with no outliers kept %d of %d runs, dropped %v", len(kept), len(runs), dropped)
+	}
+}
+
+func TestFindOutliersNeedsThreeRuns(t *testing.T) {
+	if outliers := FindOutliers(timedRuns(30000, 95000)); len(outliers) != 0 {
+		t.Errorf("FindOutliers on two runs = %+v, want none", outliers)
+	}
+}
@@ -0,0 +1,158 @@
+package analysis
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"uxbench/schema"
+)
+
+// Metadata fields Anonymize can redact.
+const (
+	RedactOperator   = "operator"    // replaced by "Operator A", "Operator B", ...
+	RedactPersona    = "persona"     // dropped
+	RedactAgentModel = "agent_model" // dropped
+	RedactURLs       = "urls"        // start URL and pages visited, hashed or dropped
+)
+
+// RedactFields lists every field Anonymize understands, and is the default set.
+var RedactFields = []string{RedactOperator, RedactPersona, RedactAgentModel, RedactURLs}
+
+// How Anonymize treats URLs.
+const (
+	URLsHash = "hash" // stable per URL, so Unique URLs and shared pages still compare
+	URLsDrop = "drop"
+)
+
+// Redaction is the set of metadata fields to strip before sharing reports.
+type Redaction struct {
+	Fields map[string]bool
+	URLs   string // URLsHash or URLsDrop
+}
+
+// ParseRedaction validates a list of field names (see RedactFields) and the
+// URL mode. An empty list redacts every field.
+func ParseRedaction(fields []string, urls string) (Redaction, error) {
+	if len(fields) == 0 {
+		fields = RedactFields
+	}
+	red := Redaction{Fields: map[string]bool{}, URLs: urls}
+	for _, f := range fields {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if !slices.Contains(RedactFields, f) {
+			return Redaction{}, fmt.Errorf("unknown field %q to redact (expected %s)", f, strings.Join(RedactFields, ", "))
+		}
+		red.Fields[f] = true
+	}
+	if urls != URLsHash && urls != URLsDrop {
+		return Redaction{}, fmt.Errorf("unknown URL mode %q (expected %s or %s)", urls, URLsHash, URLsDrop)
+	}
+	return red, nil
+}
+
+// Anonymize returns copies of reports with the redacted metadata fields
+// replaced or removed. Operators are renamed consistently across all the
+// reports, so runs by the same person still group together. Metrics, human
+// signals and the action log are left untouched.
+func Anonymize(reports []*schema.BenchmarkReport, red Redaction) []*schema.BenchmarkReport {
+	operators := map[string]string{}
+	out := make([]*schema.BenchmarkReport, len(reports))
+	for i, r := range reports {
+		c := *r
+		md := &c.Metadata
+		if red.Fields[RedactOperator] && md.Operator != "" {
+			alias, ok := operators[md.Operator]
+			if !ok {
+				alias = "Operator " + letterName(len(operators))
+				operators[md.Operator] = alias
+			}
+			md.Operator = alias
+		}
+		if red.Fields[RedactPersona] {
+			md.Persona = nil
+		}
+		if red.Fields[RedactAgentModel] {
+			md.AgentModel = nil
+		}
+		if red.Fields[RedactURLs] {
+			md.URL = redactURL(md.URL, red.URLs)
+			var visited []string
+			if red.URLs == URLsHash {
+				for _, u := range md.URLsVisited {
+					visited = append(visited, redactURL(u, red.URLs))
+				}
+			}
+			md.URLsVisited = visited
+		}
+		out[i] = &c
+	}
+	return out
+}
+
+// letterName is A, B, ... Z, then AA, AB, ... for the nth alias.
+func letterName(n int) string {
+	name := ""
+	for n >= 0 {
+		name = string(rune('A'+n%26)) + name
+		n = n/26 - 1
+	}
+	return name
+}
+
+// redactURL hashes u to a short stable token, or drops it.
+func redactURL(u, mode string) string {
+	if u == "" || mode == URLsDrop {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(u))
+	return "url-" + hex.EncodeToString(sum[:])[:12]
+}
+
+// AnonymizeJSON applies an anonymized report's metadata (from Anonymize) to
+// the original report JSON, changing only the redacted metadata keys so that
+// metrics and any fields this version doesn't know about are kept as recorded.
+func AnonymizeJSON(data []byte, md schema.BenchmarkMetadata, red Redaction) ([]byte, error) {
+	var report map[string]json.RawMessage
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	var meta map[string]json.RawMessage
+	if err := json.Unmarshal(report["metadata"], &meta); err != nil {
+		return nil, fmt.Errorf("metadata: %w", err)
+	}
+
+	set := func(key string, v any) {
+		meta[key], _ = json.Marshal(v)
+	}
+	if red.Fields[RedactOperator] {
+		if _, ok := meta["operator"]; ok {
+			set("operator", md.Operator)
+		}
+	}
+	if red.Fields[RedactPersona] {
+		delete(meta, "persona")
+	}
+	if red.Fields[RedactAgentModel] {
+		delete(meta, "agent_model")
+	}
+	if red.Fields[RedactURLs] && red.URLs == URLsDrop {
+		delete(meta, "url")
+		delete(meta, "urls_visited")
+	} else if red.Fields[RedactURLs] {
+		if _, ok := meta["url"]; ok {
+			set("url", md.URL)
+		}
+		if _, ok := meta["urls_visited"]; ok {
+			set("urls_visited", md.URLsVisited)
+		}
+	}
+
+	var err error
+	if report["metadata"], err = json.Marshal(meta); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(report, "", "    ")
+}
@@ -0,0 +1,49 @@
+package analysis
+
+import (
+	"math"
+	"uxbench/schema"
+)
+
+// compositeTolerance absorbs the rounding the recorder applies when averaging runs.
+const compositeTolerance = 0.05
+
+// Contribution is one term of the composite score.
+type Contribution struct {
+	Label        string
+	Value        float64 // raw metric value
+	Weight       float64
+	Contribution float64 // Value * Weight
+}
+
+// CompositeBreakdown explains a report's composite score under a set of weights.
+type CompositeBreakdown struct {
+	Terms      []Contribution
+	Recomputed float64 // sum of the terms' contributions
+	Reported   float64 // composite_score baked into the report
+}
+
+// Discrepancy reports whether the report's own composite score disagrees with
+// the one recomputed from its metrics, e.g. because it was recorded with other weights.
+func (b CompositeBreakdown) Discrepancy() bool {
+	return math.Abs(b.Recomputed-b.Reported) > compositeTolerance
+}
+
+// BreakdownComposite recomputes r's composite score term by term, using the same
+// formula as the recorder.
+func BreakdownComposite(r *schema.BenchmarkReport, w Weights) CompositeBreakdown {
+	m := r.Metrics
+	terms := []Contribution{
+		{Label: "Context Switches", Value: float64(m.ContextSwitches.Total), Weight: w.Switches},
+		{Label: "Fitts Cumulative ID", Value: m.Fitts.CumulativeID, Weight: w.Fitts},
+		{Label: "Scroll Dist (px)", Value: m.ScrollDistance.TotalPx, Weight: w.ScrollPx},
+	}
+
+	b := CompositeBreakdown{Reported: m.CompositeScore}
+	for i := range terms {
+		terms[i].Contribution = terms[i].Value * terms[i].Weight
+		b.Recomputed += terms[i].Contribution
+	}
+	b.Terms = terms
+	return b
+}
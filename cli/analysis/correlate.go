@@ -0,0 +1,119 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"uxbench/cli/format"
+	"uxbench/schema"
+)
+
+// MinCorrelationSamples is the recommended number of reports for correlate;
+// with fewer, a handful of runs can produce strong-looking coefficients by chance.
+const MinCorrelationSamples = 10
+
+// CorrelationMatrix holds the Pearson correlation of every pair of Metrics
+// across a set of reports.
+type CorrelationMatrix struct {
+	Metrics []format.MetricDef
+	R       [][]float64 // R[i][j] correlates Metrics[i] with Metrics[j]; format.Missing when undefined
+	N       [][]int     // reports that have both values
+}
+
+// CorrelationPair is one off-diagonal cell of a CorrelationMatrix.
+type CorrelationPair struct {
+	A, B format.MetricDef
+	R    float64
+	N    int
+}
+
+// Correlate computes the Pearson correlation between each pair of defs across
+// reports. Each pair uses only the reports that have both values, and a pair
+// with fewer than three such reports, or where either metric never varies, is
+// format.Missing.
+func Correlate(reports []*schema.BenchmarkReport, defs []format.MetricDef) CorrelationMatrix {
+	values := make([][]float64, len(defs))
+	for i, def := range defs {
+		values[i] = make([]float64, len(reports))
+		for j, r := range reports {
+			values[i][j] = def.Value(r)
+		}
+	}
+
+	m := CorrelationMatrix{Metrics: defs, R: make([][]float64, len(defs)), N: make([][]int, len(defs))}
+	for i := range defs {
+		m.R[i] = make([]float64, len(defs))
+		m.N[i] = make([]int, len(defs))
+	}
+	for i := range defs {
+		for j := i; j < len(defs); j++ {
+			var xs, ys []float64
+			for k := range reports {
+				if x, y := values[i][k], values[j][k]; !format.IsMissing(x) && !format.IsMissing(y) {
+					xs, ys = append(xs, x), append(ys, y)
+				}
+			}
+			r := Pearson(xs, ys)
+			m.R[i][j], m.R[j][i] = r, r
+			m.N[i][j], m.N[j][i] = len(xs), len(xs)
+		}
+	}
+	return m
+}
+
+// Pearson returns the Pearson correlation coefficient of xs and ys, or
+// format.Missing when there are fewer than three pairs or either side is constant.
+func Pearson(xs, ys []float64) float64 {
+	if len(xs) < 3 || len(xs) != len(ys) {
+		return format.Missing
+	}
+	mx, sx := meanStdDev(xs)
+	my, sy := meanStdDev(ys)
+	if sx == 0 || sy == 0 {
+		return format.Missing
+	}
+	cov := 0.0
+	for i := range xs {
+		cov += (xs[i] - mx) * (ys[i] - my)
+	}
+	r := cov / float64(len(xs)) / (sx * sy)
+	return math.Max(-1, math.Min(1, r)) // clamp rounding error
+}
+
+// Varying returns the defs that take at least two distinct values across
+// reports; the rest can't correlate with anything.
+func Varying(reports []*schema.BenchmarkReport, defs []format.MetricDef) []format.MetricDef {
+	var out []format.MetricDef
+	for _, def := range defs {
+		first := format.Missing
+		for _, r := range reports {
+			v := def.Value(r)
+			if format.IsMissing(v) {
+				continue
+			}
+			if format.IsMissing(first) {
+				first = v
+			} else if v != first {
+				out = append(out, def)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// Strongest returns the matrix's defined off-diagonal pairs, strongest
+// (largest |r|) first.
+func (m CorrelationMatrix) Strongest() []CorrelationPair {
+	var pairs []CorrelationPair
+	for i := range m.Metrics {
+		for j := i + 1; j < len(m.Metrics); j++ {
+			if !format.IsMissing(m.R[i][j]) {
+				pairs = append(pairs, CorrelationPair{A: m.Metrics[i], B: m.Metrics[j], R: m.R[i][j], N: m.N[i][j]})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(a, b int) bool {
+		return math.Abs(pairs[a].R) > math.Abs(pairs[b].R)
+	})
+	return pairs
+}
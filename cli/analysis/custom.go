@@ -0,0 +1,130 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"uxbench/cli/format"
+	"uxbench/schema"
+)
+
+// CustomMetric is a derived metric defined in the config file as an
+// expression over existing metric keys, e.g.
+// "total_clicks / (time_on_task_ms / 60000)" for clicks per minute.
+type CustomMetric struct {
+	Label          string `yaml:"label"`
+	Key            string `yaml:"key"` // default: derived from Label
+	Expr           string `yaml:"expr"`
+	Description    string `yaml:"description"` // shown by explain; default: the expression
+	HigherIsBetter bool   `yaml:"higher_is_better"`
+	Unit           string `yaml:"unit"`
+	Format         string `yaml:"format"` // float (default), integer, percent or ms
+}
+
+var valueFormats = map[string]format.ValueFormat{
+	"":        format.FormatFloat,
+	"float":   format.FormatFloat,
+	"integer": format.FormatInteger,
+	"percent": format.FormatPercent,
+	"ms":      format.FormatMilliseconds,
+}
+
+// RegisterCustomMetrics compiles each custom metric and adds it to the
+// registry, in order, so later metrics may refer to earlier ones.
+func RegisterCustomMetrics(metrics []CustomMetric) error {
+	for _, m := range metrics {
+		def, err := m.compile()
+		if err != nil {
+			return err
+		}
+		if err := format.RegisterMetric(def); err != nil {
+			return fmt.Errorf("custom metric %q: %w", m.Label, err)
+		}
+	}
+	return nil
+}
+
+// compile parses the expression and builds the registry entry for m.
+func (m CustomMetric) compile() (format.MetricDef, error) {
+	key := m.Key
+	if key == "" {
+		key = normalizeKey(m.Label)
+	}
+	vf, ok := valueFormats[m.Format]
+	if !ok {
+		return format.MetricDef{}, fmt.Errorf("custom metric %q: unknown format %q (expected float, integer, percent or ms)", m.Label, m.Format)
+	}
+	expr, err := ParseExpr(m.Expr)
+	if err != nil {
+		return format.MetricDef{}, fmt.Errorf("custom metric %q: %w", m.Label, err)
+	}
+	description := m.Description
+	if description == "" {
+		description = "Custom metric: " + m.Expr
+	}
+	return format.MetricDef{
+		Label:           m.Label,
+		Key:             key,
+		Description:     description,
+		ReportExtractor: expr.Value,
+		HigherIsBetter:  m.HigherIsBetter,
+		Unit:            m.Unit,
+		Format:          vf,
+	}, nil
+}
+
+// Expr is a parsed arithmetic expression over metric keys.
+type Expr struct {
+	Source string
+	root   node
+}
+
+// ParseExpr parses an arithmetic expression (+ - * / and parentheses) whose
+// identifiers are registry metric keys.
+func ParseExpr(src string) (*Expr, error) {
+	p := &parser{src: src}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	root, err := p.expr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", src, err)
+	}
+	if p.pos != len(p.toks) {
+		return nil, fmt.Errorf("invalid expression %q: unexpected %q", src, p.toks[p.pos])
+	}
+	if err := checkIdents(root); err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", src, err)
+	}
+	return &Expr{Source: src, root: root}, nil
+}
+
+// checkIdents validates that every identifier names a registry metric.
+func checkIdents(n node) error {
+	switch n := n.(type) {
+	case identNode:
+		if _, ok := lookupMetric(string(n)); !ok {
+			return fmt.Errorf("unknown metric %q", string(n))
+		}
+	case binaryNode:
+		if err := checkIdents(n.l); err != nil {
+			return err
+		}
+		return checkIdents(n.r)
+	case negNode:
+		return checkIdents(n.x)
+	}
+	return nil
+}
+
+// Value evaluates the expression for r. Missing inputs and division by zero
+// give format.Missing.
+func (e *Expr) Value(r *schema.BenchmarkReport) float64 {
+	v := e.root.eval(func(name string) float64 {
+		def, _ := lookupMetric(name)
+		return def.Value(r)
+	})
+	if math.IsInf(v, 0) {
+		return format.Missing
+	}
+	return v
+}
@@ -0,0 +1,116 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"uxbench/cli/format"
+	"uxbench/schema"
+)
+
+// RankEntry is one row of a leaderboard.
+type RankEntry struct {
+	Rank          int                     `json:"rank"`
+	Product       string                  `json:"product"`
+	Task          string                  `json:"task"`
+	SchemaVersion string                  `json:"schema_version"`
+	Value         float64                 `json:"value"`
+	TieBreak      float64                 `json:"tie_break"`
+	Report        *schema.BenchmarkReport `json:"-"`
+}
+
+// Sort orders for ParseRankBy and WithOrder. The default follows the
+// metric's HigherIsBetter.
+const (
+	OrderAsc  = "asc"  // lowest value ranks first
+	OrderDesc = "desc" // highest value ranks first
+)
+
+// ParseRankBy resolves a --by value: a metric label or key, optionally
+// suffixed with ":asc" or ":desc" (e.g. "time_on_task_ms:asc"). It returns
+// the metric and the order named by the suffix, or "" when there is none.
+func ParseRankBy(spec string) (format.MetricDef, string, error) {
+	name, order := spec, ""
+	if i := strings.LastIndex(spec, ":"); i >= 0 {
+		if o := strings.ToLower(spec[i+1:]); o == OrderAsc || o == OrderDesc {
+			name, order = spec[:i], o
+		}
+	}
+	def, ok := FindMetric(name)
+	if !ok {
+		return format.MetricDef{}, "", fmt.Errorf("unknown metric %q", name)
+	}
+	return def, order, nil
+}
+
+// WithOrder returns def ranked in the given order instead of its natural
+// direction. An empty order leaves def unchanged.
+func WithOrder(def format.MetricDef, order string) format.MetricDef {
+	switch order {
+	case OrderAsc:
+		def.HigherIsBetter = false
+	case OrderDesc:
+		def.HigherIsBetter = true
+	}
+	return def
+}
+
+// Order names the direction def ranks in.
+func Order(def format.MetricDef) string {
+	if def.HigherIsBetter {
+		return OrderDesc
+	}
+	return OrderAsc
+}
+
+// Rank orders reports best-first by the given metric. Ties on the metric are
+// broken by total clicks (fewer is better), or by composite score when ranking
+// by clicks. Pass a metric through WithOrder to rank against its natural
+// direction. Reports that still tie share a rank number (1, 1, 3, ...).
+// Reports missing the metric can't be placed and are left out.
+func Rank(reports []*schema.BenchmarkReport, by format.MetricDef) []RankEntry {
+	tie, _ := FindMetric("Total Clicks")
+	if tie.Label == by.Label {
+		tie, _ = FindMetric("Composite Score")
+	}
+
+	better := func(a, b float64, def format.MetricDef) bool {
+		if def.HigherIsBetter {
+			return a > b
+		}
+		return a < b
+	}
+
+	entries := make([]RankEntry, 0, len(reports))
+	for _, r := range reports {
+		v := by.Value(r)
+		if format.IsMissing(v) {
+			continue
+		}
+		entries = append(entries, RankEntry{
+			Product:       r.Metadata.Product,
+			Task:          r.Metadata.Task,
+			SchemaVersion: format.SchemaVersion(r),
+			Value:         v,
+			TieBreak:      tie.Value(r),
+			Report:        r,
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if !format.SameValue(a.Value, b.Value) {
+			return better(a.Value, b.Value, by)
+		}
+		return better(a.TieBreak, b.TieBreak, tie)
+	})
+
+	for i := range entries {
+		if i > 0 && format.SameValue(entries[i].Value, entries[i-1].Value) && format.SameValue(entries[i].TieBreak, entries[i-1].TieBreak) {
+			entries[i].Rank = entries[i-1].Rank
+		} else {
+			entries[i].Rank = i + 1
+		}
+	}
+	return entries
+}
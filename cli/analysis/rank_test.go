@@ -0,0 +1,51 @@
+package analysis
+
+import (
+	"testing"
+
+	"uxbench/schema"
+)
+
+// rankReport builds a report with the given clicks and, unless activeMS is
+// negative, an active time (older recordings don't carry one).
+func rankReport(product string, clicks, activeMS int) *schema.BenchmarkReport {
+	r := &schema.BenchmarkReport{}
+	r.Metadata.Product = product
+	r.Metrics.ClickCount.Total = clicks
+	if activeMS >= 0 {
+		r.Metrics.TimeOnTask.ActiveMS = &activeMS
+	}
+	return r
+}
+
+func TestRankSkipsMissing(t *testing.T) {
+	reports := []*schema.BenchmarkReport{
+		rankReport("Old", 5, -1),
+		rankReport("Slow", 10, 9000),
+		rankReport("Fast", 12, 4000),
+		rankReport("Older", 3, -1),
+	}
+	by, order, err := ParseRankBy("active_time_ms")
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := Rank(reports, WithOrder(by, order))
+	want := []string{"Fast", "Slow"}
+	if len(entries) != len(want) {
+		t.Fatalf("Rank returned %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for i, e := range entries {
+		if e.Product != want[i] || e.Rank != i+1 {
+			t.Errorf("entry %d = %s (rank %d), want %s (rank %d)", i, e.Product, e.Rank, want[i], i+1)
+		}
+	}
+
+	if entries := Rank(reports[:1], by); len(entries) != 0 {
+		t.Errorf("ranking only reports missing the metric returned %+v", entries)
+	}
+	// Clicks are always present, so every report is placed.
+	clicks, _, _ := ParseRankBy("total_clicks")
+	if entries := Rank(reports, clicks); len(entries) != len(reports) || entries[0].Product != "Older" {
+		t.Errorf("Rank by clicks = %+v, want all %d reports led by Older", entries, len(reports))
+	}
+}
@@ -0,0 +1,373 @@
+// Package analysis evaluates comparison results beyond simple formatting,
+// such as CI threshold checks.
+package analysis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"uxbench/cli/format"
+	"uxbench/schema"
+)
+
+// Threshold is a parsed --fail-if expression such as
+// "composite_score < 70" or "total_clicks > baseline*1.1".
+//
+// Identifiers name registry metrics by their snake_case key. Inside the
+// expression, "baseline" is the baseline report's value of the left-hand
+// metric, and "baseline.<key>" is the baseline value of any metric.
+type Threshold struct {
+	Source   string
+	metric   string // left-hand metric key, used to resolve bare "baseline"
+	relative bool   // references the baseline, so isn't checked against the baseline itself
+	lhs      node
+	op       string
+	rhs      node
+}
+
+// Violation records a report that tripped a threshold.
+type Violation struct {
+	Threshold string
+	Metric    string // key of the left-hand metric
+	Product   string
+	Report    *schema.BenchmarkReport
+	Actual    float64
+	Limit     float64
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("%s: %q failed (%s vs %s)", v.Product, v.Threshold, format.Fixed(v.Actual), format.Fixed(v.Limit))
+}
+
+// normalizeKey turns a user-typed metric name into key form,
+// e.g. "Time on Task (ms)" -> "time_on_task_ms".
+func normalizeKey(label string) string {
+	var sb strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(label) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if underscore && sb.Len() > 0 {
+				sb.WriteByte('_')
+			}
+			underscore = false
+			sb.WriteRune(r)
+		} else {
+			underscore = true
+		}
+	}
+	return sb.String()
+}
+
+func lookupMetric(key string) (format.MetricDef, bool) {
+	key = format.CanonicalKey(key)
+	for _, def := range format.MetricRegistry {
+		if def.Key == key {
+			return def, true
+		}
+	}
+	return format.MetricDef{}, false
+}
+
+// FindMetric resolves a user-supplied metric name, accepting the registry
+// label (case-insensitive), its snake_case key, or a unit-free alias of the
+// key (time_on_task for time_on_task_ms).
+func FindMetric(name string) (format.MetricDef, bool) {
+	for _, def := range format.MetricRegistry {
+		if strings.EqualFold(def.Label, name) {
+			return def, true
+		}
+	}
+	return lookupMetric(normalizeKey(name))
+}
+
+// SelectMetrics resolves a comma-separated list of metric keys (or labels), in
+// order, for --metrics. Unknown names are an error listing the valid keys.
+func SelectMetrics(spec string) ([]format.MetricDef, error) {
+	var defs []format.MetricDef
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		def, ok := FindMetric(name)
+		if !ok {
+			keys := make([]string, len(format.MetricRegistry))
+			for i, d := range format.MetricRegistry {
+				keys[i] = d.Key
+			}
+			return nil, fmt.Errorf("unknown metric %q; valid metrics: %s", name, strings.Join(keys, ", "))
+		}
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+// ParseThreshold parses a comparison expression. The left-hand side must
+// start with a metric key.
+func ParseThreshold(src string) (*Threshold, error) {
+	p := &parser{src: src}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+
+	t := &Threshold{Source: src}
+	if len(p.toks) > 0 {
+		if _, ok := lookupMetric(p.toks[0]); ok {
+			t.metric = p.toks[0]
+		}
+	}
+	if t.metric == "" {
+		return nil, fmt.Errorf("invalid threshold %q: must start with a metric name", src)
+	}
+
+	var err error
+	if t.lhs, err = p.expr(); err != nil {
+		return nil, fmt.Errorf("invalid threshold %q: %w", src, err)
+	}
+	switch op := p.next(); op {
+	case "<", "<=", ">", ">=", "==", "!=":
+		t.op = op
+	default:
+		return nil, fmt.Errorf("invalid threshold %q: expected comparison operator, got %q", src, op)
+	}
+	if t.rhs, err = p.expr(); err != nil {
+		return nil, fmt.Errorf("invalid threshold %q: %w", src, err)
+	}
+	if p.pos != len(p.toks) {
+		return nil, fmt.Errorf("invalid threshold %q: unexpected %q", src, p.toks[p.pos])
+	}
+	if err := t.check(t.lhs); err != nil {
+		return nil, err
+	}
+	if err := t.check(t.rhs); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// check validates that every identifier resolves to a metric.
+func (t *Threshold) check(n node) error {
+	switch n := n.(type) {
+	case identNode:
+		key := strings.TrimPrefix(string(n), "baseline.")
+		if key != string(n) || key == "baseline" {
+			t.relative = true
+		}
+		if key == "baseline" {
+			return nil
+		}
+		if _, ok := lookupMetric(key); !ok {
+			return fmt.Errorf("invalid threshold %q: unknown metric %q", t.Source, key)
+		}
+	case binaryNode:
+		if err := t.check(n.l); err != nil {
+			return err
+		}
+		return t.check(n.r)
+	case negNode:
+		return t.check(n.x)
+	}
+	return nil
+}
+
+// Evaluate reports whether report violates the threshold (true = violation),
+// resolving "baseline" identifiers against baseline.
+func (t *Threshold) Evaluate(report, baseline *schema.BenchmarkReport) (bool, float64, float64) {
+	env := func(name string) float64 {
+		r := report
+		if name == "baseline" {
+			name, r = t.metric, baseline
+		} else if strings.HasPrefix(name, "baseline.") {
+			name, r = strings.TrimPrefix(name, "baseline."), baseline
+		}
+		def, _ := lookupMetric(name)
+		return def.Value(r)
+	}
+	l, r := t.lhs.eval(env), t.rhs.eval(env)
+	// A report without the metric can't violate a threshold on it
+	if format.IsMissing(l) || format.IsMissing(r) {
+		return false, l, r
+	}
+
+	var holds bool
+	switch t.op {
+	case "<":
+		holds = l < r
+	case "<=":
+		holds = l <= r
+	case ">":
+		holds = l > r
+	case ">=":
+		holds = l >= r
+	case "==":
+		holds = l == r
+	case "!=":
+		holds = l != r
+	}
+	return holds, l, r
+}
+
+// CheckThresholds evaluates every threshold against every report and returns the violations.
+func CheckThresholds(thresholds []*Threshold, reports []*schema.BenchmarkReport, baseline *schema.BenchmarkReport) []Violation {
+	var out []Violation
+	for _, t := range thresholds {
+		for _, r := range reports {
+			if t.relative && r == baseline {
+				continue
+			}
+			if failed, l, rv := t.Evaluate(r, baseline); failed {
+				out = append(out, Violation{Threshold: t.Source, Metric: t.metric, Product: r.Metadata.Product, Report: r, Actual: l, Limit: rv})
+			}
+		}
+	}
+	return out
+}
+
+// --- expression parsing ---
+
+type node interface {
+	eval(env func(string) float64) float64
+}
+
+type numNode float64
+type identNode string
+type negNode struct{ x node }
+type binaryNode struct {
+	op   byte
+	l, r node
+}
+
+func (n numNode) eval(func(string) float64) float64       { return float64(n) }
+func (n identNode) eval(env func(string) float64) float64 { return env(string(n)) }
+func (n negNode) eval(env func(string) float64) float64   { return -n.x.eval(env) }
+func (n binaryNode) eval(env func(string) float64) float64 {
+	l, r := n.l.eval(env), n.r.eval(env)
+	switch n.op {
+	case '+':
+		return l + r
+	case '-':
+		return l - r
+	case '*':
+		return l * r
+	default:
+		return l / r
+	}
+}
+
+type parser struct {
+	src  string
+	toks []string
+	pos  int
+}
+
+func (p *parser) tokenize() error {
+	s := p.src
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case strings.ContainsRune("<>=!", rune(c)):
+			if i+1 < len(s) && s[i+1] == '=' {
+				p.toks = append(p.toks, s[i:i+2])
+				i += 2
+			} else if c == '<' || c == '>' {
+				p.toks = append(p.toks, s[i:i+1])
+				i++
+			} else {
+				return fmt.Errorf("invalid threshold %q: unexpected %q", p.src, c)
+			}
+		case strings.ContainsRune("+-*/()", rune(c)):
+			p.toks = append(p.toks, s[i:i+1])
+			i++
+		case c == '.' || c == '_' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)):
+			j := i
+			for j < len(s) && (s[j] == '.' || s[j] == '_' || unicode.IsLetter(rune(s[j])) || unicode.IsDigit(rune(s[j]))) {
+				j++
+			}
+			p.toks = append(p.toks, s[i:j])
+			i = j
+		default:
+			return fmt.Errorf("invalid threshold %q: unexpected %q", p.src, c)
+		}
+	}
+	return nil
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return ""
+}
+
+func (p *parser) next() string {
+	t := p.peek()
+	if t != "" {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expr() (node, error) {
+	l, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "+" || p.peek() == "-" {
+		op := p.next()[0]
+		r, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		l = binaryNode{op: op, l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) term() (node, error) {
+	l, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "*" || p.peek() == "/" {
+		op := p.next()[0]
+		r, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		l = binaryNode{op: op, l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) factor() (node, error) {
+	t := p.next()
+	switch {
+	case t == "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case t == "-":
+		x, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return negNode{x}, nil
+	case t == "(":
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		return x, nil
+	}
+	if v, err := strconv.ParseFloat(t, 64); err == nil {
+		return numNode(v), nil
+	}
+	if unicode.IsLetter(rune(t[0])) || t[0] == '_' {
+		return identNode(t), nil
+	}
+	return nil, fmt.Errorf("unexpected %q", t)
+}
@@ -0,0 +1,75 @@
+package analysis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"uxbench/schema"
+)
+
+// TimeWindow bounds reports by Metadata.Timestamp (see --since/--until).
+// A zero bound is open.
+type TimeWindow struct {
+	Since, Until time.Time
+}
+
+// IsZero reports whether w lets every report through.
+func (w TimeWindow) IsZero() bool {
+	return w.Since.IsZero() && w.Until.IsZero()
+}
+
+// Contains reports whether t falls within w, bounds included.
+func (w TimeWindow) Contains(t time.Time) bool {
+	return (w.Since.IsZero() || !t.Before(w.Since)) && (w.Until.IsZero() || !t.After(w.Until))
+}
+
+// Filter splits reports into those recorded within w and counts the rest:
+// outside the window, or without a timestamp to check.
+func (w TimeWindow) Filter(reports []*schema.BenchmarkReport) (kept []int, outside, undated int) {
+	for i, r := range reports {
+		switch {
+		case r.Metadata.Timestamp.IsZero():
+			undated++
+		case w.Contains(r.Metadata.Timestamp):
+			kept = append(kept, i)
+		default:
+			outside++
+		}
+	}
+	return kept, outside, undated
+}
+
+// ParseTimeBound reads a --since/--until value: an age before now ("7d",
+// "2w", or a Go duration such as "36h"), an RFC 3339 timestamp, or a date
+// (YYYY-MM-DD, midnight UTC).
+func ParseTimeBound(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	if age, ok := parseAge(s); ok {
+		return now.Add(-age), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (expected an age like 7d, 2w or 36h, a date like 2024-05-01, or an RFC 3339 timestamp)", s)
+}
+
+// parseAge accepts time.ParseDuration units plus d (days) and w (weeks).
+func parseAge(s string) (time.Duration, bool) {
+	unit := map[byte]time.Duration{'d': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	if n := len(s); n > 1 && unit[s[n-1]] != 0 {
+		v, err := strconv.ParseFloat(s[:n-1], 64)
+		if err != nil || v < 0 {
+			return 0, false
+		}
+		return time.Duration(v * float64(unit[s[n-1]])), true
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, false
+	}
+	return d, true
+}
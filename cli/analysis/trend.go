@@ -0,0 +1,125 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"uxbench/cli/format"
+	"uxbench/schema"
+)
+
+// TrendFlatPct is the largest fitted change across a series, as a percentage
+// of its mean magnitude, that still counts as flat.
+const TrendFlatPct = 1.0
+
+// Trend directions: which way a metric's values moved over the runs.
+const (
+	TrendUp   = "up"
+	TrendDown = "down"
+	TrendFlat = "flat"
+)
+
+// MetricTrend is one metric over a time series of runs, oldest first.
+type MetricTrend struct {
+	Metric    format.MetricDef
+	Values    []float64 // one per run, format.Missing where a run lacks the metric
+	Delta     float64   // last defined value minus the first; format.Missing with fewer than two
+	Slope     float64   // least-squares change per run; format.Missing with fewer than two values
+	Direction string    // TrendUp, TrendDown or TrendFlat, from Slope
+}
+
+// Outcome names what Direction means for def: "better", "worse" or "flat".
+func (t MetricTrend) Outcome() string {
+	switch {
+	case t.Direction == TrendFlat:
+		return TrendFlat
+	case (t.Direction == TrendUp) == t.Metric.HigherIsBetter:
+		return "better"
+	}
+	return "worse"
+}
+
+// SortRuns orders reports (and their paths alongside) oldest first by
+// Metadata.Timestamp. Runs recorded at the same instant are ordered by
+// recording name and then path, so the series doesn't depend on load order.
+func SortRuns(reports []*schema.BenchmarkReport, paths []string) {
+	idx := make([]int, len(reports))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		ra, rb := reports[idx[a]].Metadata, reports[idx[b]].Metadata
+		switch {
+		case !ra.Timestamp.Equal(rb.Timestamp):
+			return ra.Timestamp.Before(rb.Timestamp)
+		case ra.RecordingName != rb.RecordingName:
+			return ra.RecordingName < rb.RecordingName
+		}
+		return paths[idx[a]] < paths[idx[b]]
+	})
+	sortedReports := make([]*schema.BenchmarkReport, len(reports))
+	sortedPaths := make([]string, len(paths))
+	for i, j := range idx {
+		sortedReports[i], sortedPaths[i] = reports[j], paths[j]
+	}
+	copy(reports, sortedReports)
+	copy(paths, sortedPaths)
+}
+
+// Trends computes each def over runs, which must already be in time order
+// (see SortRuns). Metrics no run has are left out.
+func Trends(runs []*schema.BenchmarkReport, defs []format.MetricDef) []MetricTrend {
+	var out []MetricTrend
+	for _, def := range defs {
+		t := MetricTrend{Metric: def, Delta: format.Missing, Slope: format.Missing, Direction: TrendFlat}
+		var xs, ys []float64
+		for i, r := range runs {
+			v := def.Value(r)
+			t.Values = append(t.Values, v)
+			if !format.IsMissing(v) {
+				xs, ys = append(xs, float64(i)), append(ys, v)
+			}
+		}
+		if len(ys) == 0 {
+			continue
+		}
+		if len(ys) >= 2 {
+			t.Delta = ys[len(ys)-1] - ys[0]
+			t.Slope = slope(xs, ys)
+			t.Direction = direction(t.Slope*(xs[len(xs)-1]-xs[0]), ys)
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// slope is the least-squares slope of ys over xs.
+func slope(xs, ys []float64) float64 {
+	mx, _ := meanStdDev(xs)
+	my, _ := meanStdDev(ys)
+	var num, den float64
+	for i := range xs {
+		num += (xs[i] - mx) * (ys[i] - my)
+		den += (xs[i] - mx) * (xs[i] - mx)
+	}
+	if den == 0 {
+		return 0
+	}
+	return num / den
+}
+
+// direction classifies change, the fitted change across the series, against
+// TrendFlatPct of the values' mean magnitude.
+func direction(change float64, ys []float64) string {
+	scale := 0.0
+	for _, y := range ys {
+		scale += math.Abs(y)
+	}
+	scale /= float64(len(ys))
+	switch {
+	case math.Abs(change) <= scale*TrendFlatPct/100 || format.SameValue(change, 0):
+		return TrendFlat
+	case change > 0:
+		return TrendUp
+	}
+	return TrendDown
+}
@@ -0,0 +1,16 @@
+package analysis
+
+// Weights are the composite score coefficients. Each one normalizes its metric's
+// contribution so that a score of ~25 represents moderate UX friction.
+type Weights struct {
+	Switches float64 `yaml:"switches"`  // per input mode switch
+	Fitts    float64 `yaml:"fitts"`     // per bit of cumulative Fitts ID
+	ScrollPx float64 `yaml:"scroll_px"` // per pixel scrolled
+}
+
+// DefaultWeights mirrors COMPOSITE_WEIGHTS in the recorder's background worker.
+var DefaultWeights = Weights{
+	Switches: 1.5,
+	Fitts:    1.0,
+	ScrollPx: 0.005,
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"uxbench/cli/analysis"
+	"uxbench/cli/loader"
+	"uxbench/cli/logging"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	anonymizeRedact []string
+	anonymizeURLs   string
+	anonymizeOutDir string
+)
+
+var anonymizeCmd = &cobra.Command{
+	Use:   "anonymize [file|dir|url] ...",
+	Short: "Write copies of reports with identifying metadata removed",
+	Long: `Write a sanitized copy of each report for sharing outside the team. Operators
+become "Operator A", "Operator B", ... (consistently across the files), persona
+and agent model are dropped, and URLs are hashed (or dropped with --urls drop).
+Metrics are never changed. Each copy is written as <name>.anon.json.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !cmd.Flags().Changed("redact") {
+			anonymizeRedact = cfg.Redact
+		}
+		if !cmd.Flags().Changed("urls") && cfg.RedactURLs != "" {
+			anonymizeURLs = cfg.RedactURLs
+		}
+		red, err := analysis.ParseRedaction(anonymizeRedact, anonymizeURLs)
+		if err != nil {
+			return err
+		}
+
+		paths, err := loader.ExpandPaths(args)
+		if err != nil {
+			return err
+		}
+		reports, err := loadReports(paths)
+		if err != nil {
+			return err
+		}
+
+		for i, r := range analysis.Anonymize(reports, red) {
+			out := filepath.Join(anonymizeOutDir, anonymizedName(paths[i]))
+			raw, err := loader.ReadSource(paths[i])
+			if err != nil {
+				return err
+			}
+			data, err := analysis.AnonymizeJSON(raw, r.Metadata, red)
+			if err != nil {
+				return fmt.Errorf("failed to anonymize %s: %w", paths[i], err)
+			}
+			if err := os.WriteFile(out, append(data, '\n'), 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", out, err)
+			}
+			logging.Infof("Wrote %s", out)
+		}
+		return nil
+	},
+}
+
+// anonymizedName is the file name for the sanitized copy of p, e.g.
+// "runs/notion.json" -> "notion.anon.json".
+func anonymizedName(p string) string {
+	base := filepath.Base(p)
+	if loader.IsURL(p) {
+		base = path.Base(strings.SplitN(p, "?", 2)[0])
+	}
+	return strings.TrimSuffix(base, filepath.Ext(base)) + ".anon.json"
+}
+
+// configRedaction is the redaction set from the config file, for
+// compare --anonymize.
+func configRedaction() (analysis.Redaction, error) {
+	urls := cfg.RedactURLs
+	if urls == "" {
+		urls = analysis.URLsHash
+	}
+	return analysis.ParseRedaction(cfg.Redact, urls)
+}
+
+func init() {
+	anonymizeCmd.Flags().StringSliceVar(&anonymizeRedact, "redact", nil, "Fields to redact: "+strings.Join(analysis.RedactFields, ", ")+" (default all, or the config file's redact list)")
+	anonymizeCmd.Flags().StringVar(&anonymizeURLs, "urls", analysis.URLsHash, "What to do with URLs: hash (stable, so shared pages still match) or drop")
+	anonymizeCmd.Flags().StringVarP(&anonymizeOutDir, "output-dir", "o", ".", "Directory to write the sanitized copies to")
+	rootCmd.AddCommand(anonymizeCmd)
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+var colorMode string
+
+// applyColor sets the color profile every lipgloss style renders with.
+// "auto" keeps lipgloss's own detection (color only on a terminal); NO_COLOR
+// turns color off whatever the flag says (https://no-color.org).
+// Markdown, CSV, ASCII and the other file formats never contain ANSI codes.
+func applyColor(mode string) error {
+	switch mode {
+	case "auto", "always", "never":
+	default:
+		return fmt.Errorf("invalid --color %q (expected auto, always or never)", mode)
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		mode = "never"
+	}
+	switch mode {
+	case "never":
+		lipgloss.SetColorProfile(termenv.Ascii)
+	case "always":
+		lipgloss.SetColorProfile(termenv.ANSI256)
+	}
+	return nil
+}
@@ -1,8 +1,16 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"uxbench/cli/analysis"
+	"uxbench/cli/format"
 	"uxbench/cli/loader"
+	"uxbench/cli/logging"
 	"uxbench/cli/tui"
 	"uxbench/schema"
 
@@ -10,46 +18,429 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	compareMax        int
+	compareFailIf     []string
+	compareBaseline   string
+	compareFormat     string
+	compareTranspose  bool
+	compareOutput     string
+	compareWatch      bool
+	compareMetrics    string
+	compareSortBy     string
+	compareJSONErrs   bool
+	compareDryRun     bool
+	compareFooter     bool
+	compareAnonymize  bool
+	compareGroupBy    string
+	compareSplit      int
+	compareCSVDelim   string
+	compareCSVDecimal string
+	compareCSVEscape  bool
+)
+
 var compareCmd = &cobra.Command{
-	Use:   "compare [file1] [file2] ...",
+	Use:   "compare [file|dir|url|csv] [file|dir|url|csv] ...",
 	Short: "Compare multiple benchmark recordings",
 	Long:  `Compare efficiency metrics between two or more product recordings.`,
 	Args:  cobra.ArbitraryArgs, // Allow any number of args
 	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := expandDirs(args)
+		if err != nil {
+			return err
+		}
+		if compareDryRun {
+			return printDryRun(args)
+		}
+		if len(compareFailIf) > 0 {
+			return runThresholds(cmd, args)
+		}
+		if compareJSONErrs {
+			return fmt.Errorf("--json-errors requires --fail-if")
+		}
+		if !cmd.Flags().Changed("format") && cfg.Format != "" {
+			compareFormat = cfg.Format
+		}
+
+		// The picker needs two files before it can continue, so a lower cap
+		// would leave it stuck
+		if compareMax != 0 && compareMax < 2 {
+			return fmt.Errorf("--max must be 0 (unlimited) or at least 2, got %d", compareMax)
+		}
+		if compareAnonymize && (len(args) == 0 || compareWatch) {
+			return fmt.Errorf("--anonymize needs report files and can't be combined with --watch")
+		}
+		if compareWatch && (len(args) == 0 || compareFormat != "tui") {
+			return fmt.Errorf("--watch requires report files and the tui format")
+		}
+		for _, a := range args {
+			if compareWatch && loader.IsURL(a) {
+				return fmt.Errorf("--watch only supports local files, not %s", a)
+			}
+			if compareWatch && isCSV(a) {
+				return fmt.Errorf("--watch only supports JSON reports, not %s", a)
+			}
+		}
+
+		if (timeSince != "" || timeUntil != "") && len(args) == 0 {
+			return fmt.Errorf("--since and --until need report files or directories")
+		}
+		opts, err := formatOptions(compareMetrics, compareSortBy, compareGroupBy, compareTranspose, compareFooter, compareSplit)
+		if err != nil {
+			return err
+		}
+		if opts, err = withCSVSeparators(opts, compareCSVDelim, compareCSVDecimal); err != nil {
+			return err
+		}
+		opts.CSVEscapeFormulas = compareCSVEscape
+
 		if len(args) == 0 {
 			// Interactive Flow (Picker -> Results)
-			flow := tui.NewCompareFlowModel()
-			p := tea.NewProgram(flow)
+			flow := tui.NewCompareFlowModel(compareMax)
+			flow.Options = opts
+			p := tea.NewProgram(flow, tuiProgramOptions...)
 			if _, err := p.Run(); err != nil {
 				return err
 			}
 			return nil
 		}
-		
+
 		// If args provided, load them directly into ResultsModel (bypassing Picker)
-		// Load reports
-		reports := make([]*schema.BenchmarkReport, len(args))
-		for i, f := range args {
-			r, err := loader.LoadReport(f)
+		reports, loaded, err := loadValidReports(args, 1)
+		if err != nil {
+			return err
+		}
+		if reports, loaded, err = filterByTime(reports, loaded, 1); err != nil {
+			return err
+		}
+		if compareAnonymize {
+			red, err := configRedaction()
+			if err != nil {
+				return err
+			}
+			reports = analysis.Anonymize(reports, red)
+		}
+		reports = format.GroupReports(reports, opts.GroupBy)
+		if len(reports) < 2 {
+			// Nothing to compare against: show the lone report's summary in
+			// the TUI case, and say what to do instead for file formats.
+			if compareFormat != "tui" || compareWatch {
+				return fmt.Errorf("need at least two reports to compare, got 1; use `uxbench summary %s` for a single file", loaded[0])
+			}
+			logging.Infof("Only one report loaded, so there is nothing to compare; showing its summary instead.\n")
+			fmt.Print(summaryText(reports[0]))
+			return nil
+		}
+
+		if compareFormat != "tui" && compareFormat != "md" && !slices.Contains(fileFormats, compareFormat) {
+			return fmt.Errorf("unknown format %q (expected tui, %s)", compareFormat, strings.Join(fileFormats, ", "))
+		}
+		if compareFormat != "tui" {
+			// Charts and spreadsheets go to a file so they can go straight into
+			// slides; everything else is printed (see export for writing files)
+			if (compareFormat == "svg" || compareFormat == "xlsx") && compareOutput == "" {
+				return fmt.Errorf("--format %s requires --output <file.%s>", compareFormat, compareFormat)
+			}
+			output := ""
+			if compareFormat == "svg" || compareFormat == "xlsx" {
+				output = compareOutput
+			}
+			data, err := renderReports(compareFormat, reports, opts)
+			if err != nil {
+				return err
+			}
+			return writeOutput(data, output)
+		}
+
+		if compareWatch {
+			// Reload all files when any of them changes, so every view stays consistent
+			watch, err := tui.NewWatchModel(loaded, reports, opts)
 			if err != nil {
-				return fmt.Errorf("failed to load %s: %w", f, err)
+				return err
 			}
-			reports[i] = r
+			defer watch.Close()
+			_, err = tea.NewProgram(watch, tuiProgramOptions...).Run()
+			return err
 		}
 
 		// Launch Results TUI directly
-		resultsModel := tui.NewResultsModel(reports)
-		p := tea.NewProgram(resultsModel)
+		resultsModel := tui.NewResultsModelWithOptions(reports, opts)
+		p := tea.NewProgram(resultsModel, tuiProgramOptions...)
 		if _, err := p.Run(); err != nil {
 			return err
 		}
-		
+
 		return nil
 	},
 }
 
-func init() {
-	rootCmd.AddCommand(compareCmd)
+// runThresholds evaluates the --fail-if expressions non-interactively, returning an
+// error (and so a non-zero exit code) if any report violates one.
+func runThresholds(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("--fail-if requires report files as arguments")
+	}
+
+	thresholds := make([]*analysis.Threshold, len(compareFailIf))
+	for i, expr := range compareFailIf {
+		t, err := analysis.ParseThreshold(expr)
+		if err != nil {
+			return err
+		}
+		thresholds[i] = t
+	}
+
+	reports, loaded, skipped, err := loadValidReportsSkipped(args, 1)
+	if err != nil {
+		return err
+	}
+	reports, loaded, outside, err := filterByTimeSkipped(reports, loaded, 1)
+	if err != nil {
+		return err
+	}
+	skipped = append(skipped, outside...)
+
+	baseline := reports[0]
+	if compareBaseline != "" {
+		baseline = nil
+		for i, f := range loaded {
+			if f == compareBaseline {
+				baseline = reports[i]
+				break
+			}
+		}
+		if baseline == nil {
+			return fmt.Errorf("--baseline %s is not one of the loaded files", compareBaseline)
+		}
+	}
+
+	violations := analysis.CheckThresholds(thresholds, reports, baseline)
+	if compareJSONErrs {
+		return printValidationJSON(cmd, args, loaded, skipped, reports, violations)
+	}
+	if len(violations) == 0 {
+		fmt.Printf("All %d threshold(s) passed for %d report(s).\n", len(thresholds), len(reports))
+		return nil
+	}
+
+	for _, v := range violations {
+		fmt.Fprintf(os.Stderr, "FAIL %s\n", v.Error())
+	}
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return fmt.Errorf("%d threshold violation(s)", len(violations))
 }
 
+// validationIssue is one problem found with a file, for --json-errors.
+type validationIssue struct {
+	Field    string `json:"field"` // metric key, or "file" when the file couldn't be loaded
+	Message  string `json:"message"`
+	Severity string `json:"severity"` // "error" fails the check; "warning" and "info" (a file left out on purpose) don't
+}
+
+// validationFile is one input file's entry in the --json-errors output.
+type validationFile struct {
+	File    string            `json:"file"`
+	Product string            `json:"product,omitempty"`
+	Errors  []validationIssue `json:"errors"`
+}
+
+// printValidationJSON writes the --fail-if results as JSON on stdout: every
+// input file with its issues, and overall pass/fail. Files that couldn't be
+// loaded were skipped, so they are warnings, as in the text output; files
+// skipped on purpose (see skippedFile) are info. A failed check still returns
+// an error, so the exit code is non-zero.
+func printValidationJSON(cmd *cobra.Command, args, loaded []string, skipped []skippedFile, reports []*schema.BenchmarkReport, violations []analysis.Violation) error {
+	result := struct {
+		Passed bool             `json:"passed"`
+		Files  []validationFile `json:"files"`
+	}{Passed: len(violations) == 0}
+
+	byReport := map[*schema.BenchmarkReport]int{}
+	for i, r := range reports {
+		byReport[r] = len(result.Files)
+		result.Files = append(result.Files, validationFile{File: loaded[i], Product: r.Metadata.Product, Errors: []validationIssue{}})
+	}
+	for i, sk := range skipped {
+		if slices.ContainsFunc(skipped[:i], func(prev skippedFile) bool { return prev.Path == sk.Path }) {
+			continue // a CSV's columns are skipped together
+		}
+		result.Files = append(result.Files, validationFile{File: sk.Path, Errors: []validationIssue{
+			{Field: "file", Message: sk.Reason + "; skipped", Severity: "info"},
+		}})
+	}
+	for _, a := range args {
+		if !slices.Contains(loaded, a) && !slices.ContainsFunc(skipped, func(sk skippedFile) bool { return sk.Path == a }) {
+			result.Files = append(result.Files, validationFile{File: a, Errors: []validationIssue{
+				{Field: "file", Message: "could not be loaded; skipped", Severity: "warning"},
+			}})
+		}
+	}
+	for _, v := range violations {
+		f := &result.Files[byReport[v.Report]]
+		msg := fmt.Sprintf("%q failed (%s vs %s)", v.Threshold, format.Fixed(v.Actual), format.Fixed(v.Limit))
+		f.Errors = append(f.Errors, validationIssue{Field: v.Metric, Message: msg, Severity: "error"})
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetEscapeHTML(false) // keep "<" readable in threshold expressions
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(result); err != nil {
+		return err
+	}
+	if result.Passed {
+		return nil
+	}
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return fmt.Errorf("%d threshold violation(s)", len(violations))
+}
+
+// loadReports loads every path, failing on the first unreadable file.
+func loadReports(paths []string) ([]*schema.BenchmarkReport, error) {
+	reports, errs := loader.LoadReports(paths)
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", paths[i], err)
+		}
+	}
+	return reports, nil
+}
+
+// loadValidReports loads every path it can, warning on stderr about files that
+// fail, and returns the loaded reports alongside their paths. It only fails when
+// fewer than min reports could be loaded.
+func loadValidReports(paths []string, min int) ([]*schema.BenchmarkReport, []string, error) {
+	reports, loaded, _, err := loadValidReportsSkipped(paths, min)
+	return reports, loaded, err
+}
 
+// skippedFile is a readable file left out on purpose, and why.
+type skippedFile struct {
+	Path   string
+	Reason string
+}
+
+// loadValidReportsSkipped is loadValidReports that also returns the files
+// dropped as duplicates, so they aren't mistaken for load failures.
+func loadValidReportsSkipped(paths []string, min int) ([]*schema.BenchmarkReport, []string, []skippedFile, error) {
+	var reports []*schema.BenchmarkReport
+	var loaded []string
+	var failed int
+	var jsonPaths []string
+	for _, f := range paths {
+		if !isCSV(f) {
+			jsonPaths = append(jsonPaths, f)
+		}
+	}
+	all, errs := loader.LoadReports(jsonPaths)
+	next := 0
+	for _, f := range paths {
+		if isCSV(f) {
+			imported, err := loadCSV(f)
+			if err != nil {
+				logging.Warnf("skipping %s: %v", f, err)
+				failed++
+				continue
+			}
+			for _, r := range imported {
+				reports = append(reports, r)
+				loaded = append(loaded, f)
+			}
+			continue
+		}
+		i := next
+		next++
+		if errs[i] != nil {
+			logging.Warnf("skipping %s: %v", f, errs[i])
+			failed++
+			continue
+		}
+		reports = append(reports, all[i])
+		loaded = append(loaded, f)
+	}
+	loaded, reports, skipped := dedupeReports(loaded, reports)
+	if len(reports) < min {
+		if failed > 0 {
+			return nil, nil, nil, fmt.Errorf("only %d of %d report(s) could be loaded (need at least %d)", len(reports), len(paths), min)
+		}
+		return nil, nil, nil, fmt.Errorf("need at least %d report(s), got %d", min, len(reports))
+	}
+	if failed > 0 {
+		logging.Warnf("continuing with %d of %d report(s)", len(reports), len(paths))
+	}
+	return reports, loaded, skipped, nil
+}
+
+// dedupeReports drops reports whose content repeats an earlier one (the same
+// recording saved twice under different names), logging each, unless --no-dedup.
+// It also returns the dropped files.
+func dedupeReports(paths []string, reports []*schema.BenchmarkReport) ([]string, []*schema.BenchmarkReport, []skippedFile) {
+	if noDedup {
+		return paths, reports, nil
+	}
+	paths, reports, dups := loader.Dedupe(paths, reports)
+	var skipped []skippedFile
+	for _, d := range dups {
+		logging.Infof("Skipping %s: same content as %s (use --no-dedup to keep it)", d.Path, d.Of)
+		skipped = append(skipped, skippedFile{Path: d.Path, Reason: "duplicate of " + d.Of})
+	}
+	return paths, reports, skipped
+}
+
+// expandDirs replaces the directories in paths with the reports they contain
+// (see loader.ExpandPaths). Anything else, unreadable paths included, is kept
+// for loadValidReports to load or warn about.
+func expandDirs(paths []string) ([]string, error) {
+	var out []string
+	for _, p := range paths {
+		if info, err := os.Stat(p); err != nil || !info.IsDir() {
+			out = append(out, p)
+			continue
+		}
+		expanded, err := loader.ExpandPaths([]string{p})
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, expanded...)
+	}
+	return out, nil
+}
+
+func isCSV(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".csv")
+}
+
+// loadCSV reads a comparison CSV previously written by --format csv; every
+// product column becomes a minimal report (see format.ParseCSV).
+func loadCSV(path string) ([]*schema.BenchmarkReport, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return format.ParseCSV(f)
+}
+
+func init() {
+	compareCmd.Flags().StringArrayVar(&compareFailIf, "fail-if", nil, `Exit non-zero if any report matches this expression, e.g. "composite_score < 70" or "total_clicks > baseline*1.1" (repeatable)`)
+	compareCmd.Flags().BoolVar(&compareJSONErrs, "json-errors", false, "With --fail-if, print the results as JSON (per-file errors with field, message and severity, plus overall pass/fail)")
+	compareCmd.Flags().StringVar(&compareBaseline, "baseline", "", "Report file that \"baseline\" refers to in --fail-if (default: first file)")
+	compareCmd.Flags().StringVarP(&compareFormat, "format", "f", "tui", "Output format: tui, markdown, csv, json, html, ascii, summary (one line), xlsx or svg (overrides the config file)")
+	compareCmd.Flags().StringVarP(&compareOutput, "output", "o", "", "Output file (required for xlsx and svg)")
+	compareCmd.Flags().StringVar(&compareGroupBy, "group-by", format.GroupByProduct, "Label and group the columns by product, persona or agent_model (falls back to the product when a report lacks the field)")
+	compareCmd.Flags().BoolVar(&compareAnonymize, "anonymize", false, "Redact operators, personas, agent models and URLs before rendering (set in the config file's redact list)")
+	compareCmd.Flags().BoolVar(&compareFooter, "footer", false, "Add an Overall row with each product's metrics won and average normalized score (tui/markdown/csv)")
+	compareCmd.Flags().IntVar(&compareSplit, "split-every", 0, "Split the markdown table into tables of at most this many products, winners still computed across all (0 = one table)")
+	compareCmd.Flags().StringVar(&compareCSVDelim, "csv-delimiter", ",", "CSV cell separator: one character such as , ; | or tab (csv output and TUI saves)")
+	compareCmd.Flags().StringVar(&compareCSVDecimal, "csv-decimal", ".", "CSV decimal separator: . or , (use , with --csv-delimiter \";\" for European spreadsheets)")
+	compareCmd.Flags().BoolVar(&compareCSVEscape, "csv-escape-formulas", false, "Prefix CSV product, task and metadata cells starting with = + - @ with ' so spreadsheets don't run them as formulas")
+	compareCmd.Flags().BoolVar(&compareTranspose, "transpose", false, "Render products as rows and metrics as columns (markdown/csv)")
+	compareCmd.Flags().StringVar(&compareSortBy, "sort-metrics", format.SortRegistry, "Metric row order: registry (grouped by category), spread (where products differ most first) or alpha")
+	compareCmd.Flags().StringVar(&compareMetrics, "metrics", "", "Comma-separated metric keys to show, in order (e.g. composite_score,total_clicks,time_on_task_ms)")
+	compareCmd.Flags().BoolVar(&compareWatch, "watch", false, "Reload and re-render the results whenever a compared file changes")
+	compareCmd.Flags().BoolVar(&compareDryRun, "dry-run", false, "List the files that would be loaded, with product and task and any that would be skipped, and exit")
+	addTimeWindowFlags(compareCmd)
+	compareCmd.Flags().IntVar(&compareMax, "max", 0, "Maximum number of files selectable in the interactive picker: 0 (unlimited) or at least 2")
+	rootCmd.AddCommand(compareCmd)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"uxbench/cli/config"
+	"uxbench/cli/logging"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	configInitLocal bool
+	configInitForce bool
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the uxbench config file",
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a commented default config file",
+	Long: `Write a commented default config file to $XDG_CONFIG_HOME/uxbench/config.yaml,
+or to ./` + config.LocalFile + ` with --local.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := config.LocalFile
+		if !configInitLocal {
+			p, err := config.UserPath()
+			if err != nil {
+				return err
+			}
+			path = p
+		}
+
+		if _, err := os.Stat(path); err == nil && !configInitForce {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(config.DefaultFile), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		logging.Infof("Wrote %s", path)
+		return nil
+	},
+}
+
+func init() {
+	configInitCmd.Flags().BoolVar(&configInitLocal, "local", false, "Write ./"+config.LocalFile+" instead of the user config file")
+	configInitCmd.Flags().BoolVar(&configInitForce, "force", false, "Overwrite an existing file")
+	configCmd.AddCommand(configInitCmd)
+	rootCmd.AddCommand(configCmd)
+}
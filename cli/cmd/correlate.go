@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"uxbench/cli/analysis"
+	"uxbench/cli/format"
+	"uxbench/cli/loader"
+	"uxbench/cli/logging"
+	"uxbench/schema"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	correlateMetrics    string
+	correlateDetail     bool
+	correlateMinSamples int
+	correlateFormat     string
+)
+
+var correlateCmd = &cobra.Command{
+	Use:   "correlate [file|dir] ...",
+	Short: "Show which metrics move together across many recordings",
+	Long: `Load every recording (directories are expanded to their .json files) and print
+the Pearson correlation between each pair of metrics, from -1 (one rises as the
+other falls) through 0 (unrelated) to +1 (they rise together), followed by the
+strongest pairs. Each pair uses the reports that have both values; metrics that
+never vary are left out.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paths, err := loader.ExpandPaths(args)
+		if err != nil {
+			return err
+		}
+		if len(paths) == 0 {
+			return fmt.Errorf("no .json reports found")
+		}
+		reports, loaded, err := loadValidReports(paths, 3)
+		if err != nil {
+			return err
+		}
+		if reports, _, err = filterByTime(reports, loaded, 3); err != nil {
+			return err
+		}
+		if len(reports) < correlateMinSamples {
+			logging.Warnf("only %d report(s); correlations from fewer than %d are unreliable", len(reports), correlateMinSamples)
+		}
+
+		var defs []format.MetricDef
+		if correlateMetrics != "" {
+			if defs, err = analysis.SelectMetrics(correlateMetrics); err != nil {
+				return err
+			}
+		} else {
+			for _, g := range format.GroupedMetrics(correlateDetail) {
+				defs = append(defs, g.Metrics...)
+			}
+		}
+		varying := analysis.Varying(reports, defs)
+		if skipped := len(defs) - len(varying); skipped > 0 {
+			logging.Infof("Skipping %d metric(s) with the same value in every report", skipped)
+		}
+		if len(varying) < 2 {
+			return fmt.Errorf("need at least two metrics that vary across the reports, got %d", len(varying))
+		}
+
+		m := analysis.Correlate(reports, varying)
+		switch correlateFormat {
+		case "text":
+			fmt.Print(correlationText(m, len(reports)))
+		case "json":
+			return printCorrelationJSON(m, reports)
+		case "csv":
+			w := csv.NewWriter(os.Stdout)
+			header := []string{"metric"}
+			for _, def := range m.Metrics {
+				header = append(header, def.Key)
+			}
+			w.Write(header)
+			for i, def := range m.Metrics {
+				rec := []string{def.Key}
+				for j := range m.Metrics {
+					rec = append(rec, plainR(m.R[i][j]))
+				}
+				w.Write(rec)
+			}
+			w.Flush()
+			return w.Error()
+		default:
+			return fmt.Errorf("unknown format %q (expected text, json or csv)", correlateFormat)
+		}
+		return nil
+	},
+}
+
+// correlationText renders the matrix with numbered columns (the labels are too
+// wide for headers) and then the strongest pairs.
+func correlationText(m analysis.CorrelationMatrix, reports int) string {
+	labelWidth := 0
+	for _, def := range m.Metrics {
+		labelWidth = max(labelWidth, len(def.Label))
+	}
+	numWidth := len(fmt.Sprint(len(m.Metrics)))
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Pearson correlation across %d reports\n\n", reports))
+	sb.WriteString(strings.Repeat(" ", numWidth+labelWidth+3))
+	for j := range m.Metrics {
+		sb.WriteString(fmt.Sprintf("%6d", j+1))
+	}
+	sb.WriteString("\n")
+	for i, def := range m.Metrics {
+		sb.WriteString(fmt.Sprintf("%*d. %-*s ", numWidth, i+1, labelWidth, def.Label))
+		for j := range m.Metrics {
+			cell := "n/a"
+			if i == j {
+				cell = "1"
+			} else if r := m.R[i][j]; !format.IsMissing(r) {
+				cell = fmt.Sprintf("%+.2f", r)
+			}
+			sb.WriteString(fmt.Sprintf("%6s", cell))
+		}
+		sb.WriteString("\n")
+	}
+
+	pairs, more := format.Top(m.Strongest())
+	if len(pairs) > 0 {
+		sb.WriteString("\nStrongest pairs:\n")
+		for _, p := range pairs {
+			sb.WriteString(fmt.Sprintf("  %+.2f  %s ~ %s (n=%d, %s)\n", p.R, p.A.Label, p.B.Label, p.N, strength(p.R)))
+		}
+		sb.WriteString(format.MoreLine(more))
+	}
+	return sb.String()
+}
+
+// strength describes |r| with the usual rule-of-thumb bands.
+func strength(r float64) string {
+	switch a := math.Abs(r); {
+	case a >= 0.7:
+		return "strong"
+	case a >= 0.4:
+		return "moderate"
+	case a >= 0.2:
+		return "weak"
+	}
+	return "negligible"
+}
+
+func plainR(r float64) string {
+	if format.IsMissing(r) {
+		return ""
+	}
+	return fmt.Sprintf("%.3f", r)
+}
+
+func printCorrelationJSON(m analysis.CorrelationMatrix, reports []*schema.BenchmarkReport) error {
+	type pair struct {
+		A string  `json:"a"`
+		B string  `json:"b"`
+		R float64 `json:"r"`
+		N int     `json:"n"`
+	}
+	out := struct {
+		Reports int          `json:"reports"`
+		Metrics []string     `json:"metrics"`
+		R       [][]*float64 `json:"r"`
+		N       [][]int      `json:"n"`
+		Pairs   []pair       `json:"pairs"`
+	}{Reports: len(reports), N: m.N, Pairs: []pair{}}
+	for i, def := range m.Metrics {
+		out.Metrics = append(out.Metrics, def.Key)
+		row := make([]*float64, len(m.Metrics))
+		for j := range m.Metrics {
+			row[j] = format.OptionalValue(math.Round(m.R[i][j]*1000) / 1000)
+		}
+		out.R = append(out.R, row)
+	}
+	for _, p := range m.Strongest() {
+		out.Pairs = append(out.Pairs, pair{A: p.A.Key, B: p.B.Key, R: math.Round(p.R*1000) / 1000, N: p.N})
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func init() {
+	correlateCmd.Flags().StringVar(&correlateMetrics, "metrics", "", "Comma-separated metric keys to correlate, in order (default: the metrics the comparison table shows)")
+	correlateCmd.Flags().BoolVar(&correlateDetail, "detail", false, "Also include the detail-only metrics")
+	correlateCmd.Flags().IntVar(&correlateMinSamples, "min-samples", analysis.MinCorrelationSamples, "Warn when fewer reports than this are loaded (at least 3 are always required)")
+	correlateCmd.Flags().StringVarP(&correlateFormat, "format", "f", "text", "Output format: text, json or csv")
+	addTimeWindowFlags(correlateCmd)
+	rootCmd.AddCommand(correlateCmd)
+}
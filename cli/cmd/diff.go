@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"uxbench/cli/analysis"
+	"uxbench/cli/format"
+	"uxbench/cli/loader"
+	"uxbench/cli/logging"
+	"uxbench/schema"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffFormat        string
+	diffBaselineDir   string
+	diffMaxRegression float64
+	diffKeyMetrics    string
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [baseline] [candidate]",
+	Short: "Diff two benchmark recordings metric by metric",
+	Long: `Compare a candidate recording against a baseline, showing absolute and
+percentage differences for every metric and whether each improved or regressed.
+
+With --baseline-dir, every argument is a candidate, diffed against the report in
+that directory with the same product and task. The command then fails if any
+--key-metrics regressed by more than --max-regression percent, or if a
+candidate has no baseline.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if diffBaselineDir != "" {
+			return cobra.MinimumNArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if diffFormat != "text" && diffFormat != "json" {
+			return fmt.Errorf("unknown format %q (expected text or json)", diffFormat)
+		}
+		if diffBaselineDir != "" {
+			return runBaselineDiff(cmd, args)
+		}
+
+		reports, err := loadReports(args)
+		if err != nil {
+			return err
+		}
+
+		d := format.DiffReports(reports[0], reports[1])
+		if diffFormat == "json" {
+			out, err := json.MarshalIndent(d, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+		fmt.Print(format.GenerateDiffText(d))
+		return nil
+	},
+}
+
+// baselineKey matches a candidate to its baseline: product and task, ignoring
+// case and surrounding space.
+func baselineKey(r *schema.BenchmarkReport) string {
+	return strings.ToLower(strings.TrimSpace(r.Metadata.Product)) + "\x00" + strings.ToLower(strings.TrimSpace(r.Metadata.Task))
+}
+
+// productTask names a report's product and task for messages.
+func productTask(r *schema.BenchmarkReport) string {
+	if r.Metadata.Task == "" {
+		return fmt.Sprintf("%q (no task)", r.Metadata.Product)
+	}
+	return fmt.Sprintf("%q / %q", r.Metadata.Product, r.Metadata.Task)
+}
+
+// baselineDiff is one candidate's entry in the --baseline-dir JSON output.
+type baselineDiff struct {
+	File        string              `json:"file"`
+	Baseline    string              `json:"baseline,omitempty"` // "" when no baseline matched
+	Error       string              `json:"error,omitempty"`    // why there is no diff
+	Diff        *format.ReportDiff  `json:"diff,omitempty"`
+	Regressions []format.MetricDiff `json:"regressions"`
+	Passed      bool                `json:"passed"`
+}
+
+// runBaselineDiff diffs each candidate against the matching report in
+// --baseline-dir and fails when one has no baseline or regressed.
+func runBaselineDiff(cmd *cobra.Command, args []string) error {
+	keys := strings.Split(diffKeyMetrics, ",")
+	for i, k := range keys {
+		def, ok := analysis.FindMetric(strings.TrimSpace(k))
+		if !ok {
+			return fmt.Errorf("unknown metric %q in --key-metrics", k)
+		}
+		keys[i] = def.Key
+	}
+
+	paths, err := loader.ExpandPaths([]string{diffBaselineDir})
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no baseline reports found in %s", diffBaselineDir)
+	}
+	baselines, baselineFiles, err := loadValidReports(paths, 1)
+	if err != nil {
+		return err
+	}
+	index := map[string]int{}
+	for i, b := range baselines {
+		k := baselineKey(b)
+		j, dup := index[k]
+		// Of two baselines for the same product and task, keep the newer
+		if !dup || !b.Metadata.Timestamp.Before(baselines[j].Metadata.Timestamp) {
+			index[k] = i
+		}
+		if dup {
+			logging.Warnf("%s and %s are both baselines for %s; using %s", baselineFiles[j], baselineFiles[i], productTask(b), baselineFiles[index[k]])
+		}
+	}
+	var available []string
+	for _, i := range index {
+		available = append(available, productTask(baselines[i]))
+	}
+	sort.Strings(available)
+
+	candidates, err := loadReports(args)
+	if err != nil {
+		return err
+	}
+
+	var results []baselineDiff
+	failed := 0
+	for i, c := range candidates {
+		res := baselineDiff{File: args[i], Regressions: []format.MetricDiff{}}
+		j, ok := index[baselineKey(c)]
+		if !ok {
+			res.Error = fmt.Sprintf("no baseline for %s in %s (it has %s)", productTask(c), diffBaselineDir, strings.Join(available, ", "))
+			failed++
+			results = append(results, res)
+			continue
+		}
+		d := format.DiffReports(baselines[j], c)
+		res.Baseline, res.Diff = baselineFiles[j], &d
+		if regs := d.Regressions(keys, diffMaxRegression); len(regs) > 0 {
+			res.Regressions = regs
+			failed++
+		} else {
+			res.Passed = true
+		}
+		results = append(results, res)
+	}
+
+	if diffFormat == "json" {
+		out, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+	} else {
+		for i, res := range results {
+			if i > 0 {
+				fmt.Println()
+			}
+			if res.Diff == nil {
+				fmt.Fprintf(os.Stderr, "FAIL %s: %s\n", res.File, res.Error)
+				continue
+			}
+			fmt.Printf("%s vs %s\n", res.File, res.Baseline)
+			fmt.Print(format.GenerateDiffText(*res.Diff))
+			for _, m := range res.Regressions {
+				pct := "from a zero baseline"
+				if m.PctDiff != nil {
+					pct = fmt.Sprintf("%+.1f%%", *m.PctDiff)
+				}
+				fmt.Fprintf(os.Stderr, "FAIL %s: %s regressed %s (limit %g%%)\n", res.File, m.Metric, pct, diffMaxRegression)
+			}
+		}
+	}
+
+	if failed == 0 {
+		return nil
+	}
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return fmt.Errorf("%d of %d candidate(s) failed", failed, len(candidates))
+}
+
+func init() {
+	diffCmd.Flags().StringVarP(&diffFormat, "format", "f", "text", "Output format: text or json")
+	diffCmd.Flags().StringVar(&diffBaselineDir, "baseline-dir", "", "Diff each argument against the report in this directory with the same product and task")
+	diffCmd.Flags().Float64Var(&diffMaxRegression, "max-regression", 5, "With --baseline-dir, fail when a key metric gets worse by more than this percentage")
+	diffCmd.Flags().StringVar(&diffKeyMetrics, "key-metrics", "composite_score,total_clicks,time_on_task_ms", "With --baseline-dir, comma-separated metric keys checked against --max-regression")
+	rootCmd.AddCommand(diffCmd)
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"uxbench/cli/analysis"
+	"uxbench/cli/loader"
+	"uxbench/schema"
+)
+
+// printDryRun lists the files a command would load, numbered, with the product
+// and task each contains, then the total. Files a real run would skip are
+// marked with why: unreadable, the same content as an earlier file (unless
+// --no-dedup), or recorded outside --since/--until. Nothing is rendered.
+func printDryRun(paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("--dry-run needs report files or directories")
+	}
+	window, err := timeWindow()
+	if err != nil {
+		return err
+	}
+
+	// Load the way a real run does (metrics only), so duplicates hash the same
+	var jsonPaths []string
+	for _, p := range paths {
+		if !isCSV(p) {
+			jsonPaths = append(jsonPaths, p)
+		}
+	}
+	reports, errs := loader.LoadReports(jsonPaths)
+
+	var sb strings.Builder
+	width := len(fmt.Sprint(len(paths)))
+	first := map[string]string{} // content hash -> first path, as in loader.Dedupe
+	unreadable, skipped, next := 0, 0, 0
+	for i, p := range paths {
+		label, skip := "(comparison CSV)", ""
+		if isCSV(p) {
+			if !window.IsZero() {
+				skip = "no timestamp" // imported columns carry none
+			}
+		} else {
+			r, err := reports[next], errs[next]
+			next++
+			if err != nil {
+				unreadable++
+				sb.WriteString(fmt.Sprintf("%*d. %s  (unreadable: %v)\n", width, i+1, p, err))
+				continue
+			}
+			label, skip = reportLabel(r), dryRunSkip(p, r, window, first)
+		}
+		sb.WriteString(fmt.Sprintf("%*d. %s  %s", width, i+1, p, label))
+		if skip != "" {
+			skipped++
+			sb.WriteString(fmt.Sprintf("  (skipped: %s)", skip))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf("\nTotal: %d file(s)", len(paths)))
+	if unreadable > 0 {
+		sb.WriteString(fmt.Sprintf(", %d unreadable", unreadable))
+	}
+	if skipped > 0 {
+		sb.WriteString(fmt.Sprintf(", %d skipped", skipped))
+	}
+	sb.WriteString("\n")
+	fmt.Print(sb.String())
+	return nil
+}
+
+// reportLabel names a report's product and task.
+func reportLabel(r *schema.BenchmarkReport) string {
+	if r.Metadata.Task == "" {
+		return r.Metadata.Product
+	}
+	return fmt.Sprintf("%s — %s", r.Metadata.Product, r.Metadata.Task)
+}
+
+// dryRunSkip returns why a real run would skip the report at path, or "" if
+// it would be used. Duplicates are checked first, as dedupeReports runs
+// before filterByTime; first records each content hash seen so far.
+func dryRunSkip(path string, r *schema.BenchmarkReport, window analysis.TimeWindow, first map[string]string) string {
+	if !noDedup {
+		if h, err := loader.ContentHash(r); err == nil {
+			if of, seen := first[h]; seen {
+				return "same content as " + of
+			}
+			first[h] = path
+		}
+	}
+	switch {
+	case window.IsZero():
+		return ""
+	case r.Metadata.Timestamp.IsZero():
+		return "no timestamp"
+	case !window.Contains(r.Metadata.Timestamp):
+		return "recorded outside --since/--until"
+	}
+	return ""
+}
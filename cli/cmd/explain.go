@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"uxbench/cli/analysis"
+	"uxbench/cli/format"
+
+	"github.com/spf13/cobra"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain [metric] ...",
+	Short: "Explain what each metric measures",
+	Long: `Print what a metric measures, its unit, and whether higher or lower is better.
+Name metrics by label or snake_case key; with no arguments, every metric is listed.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			fmt.Print(format.Glossary())
+			return nil
+		}
+
+		var parts []string
+		for _, name := range args {
+			def, ok := analysis.FindMetric(name)
+			if !ok {
+				return fmt.Errorf("unknown metric %q (run `uxbench explain` for the full list)", name)
+			}
+			parts = append(parts, format.Explain(def))
+		}
+		fmt.Print(strings.Join(parts, "\n"))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"uxbench/cli/analysis"
+	"uxbench/cli/format"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat     string
+	exportOutput     string
+	exportMetrics    string
+	exportSortBy     string
+	exportGroupBy    string
+	exportTranspose  bool
+	exportFooter     bool
+	exportAnonymize  bool
+	exportSplit      int
+	exportCSVDelim   string
+	exportCSVDecimal string
+	exportCSVEscape  bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [file|dir|url|csv] ...",
+	Short: "Write a comparison report to a file or stdout",
+	Long: `Load reports and write the comparison in one format, without the TUI.
+This is the scripting entry point: the output goes to --output, or stdout when
+omitted (except xlsx, which is binary). Without --format, the format is taken
+from the --output extension (.md, .csv, .json, .html, .txt, .xlsx, .svg), then
+markdown.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := exportFormat
+		if !cmd.Flags().Changed("format") {
+			if guess := formatForPath(exportOutput); guess != "" {
+				name = guess
+			}
+		}
+		if name == "xlsx" && exportOutput == "" {
+			return fmt.Errorf("--format xlsx requires --output <file.xlsx>")
+		}
+
+		opts, err := formatOptions(exportMetrics, exportSortBy, exportGroupBy, exportTranspose, exportFooter, exportSplit)
+		if err != nil {
+			return err
+		}
+		if opts, err = withCSVSeparators(opts, exportCSVDelim, exportCSVDecimal); err != nil {
+			return err
+		}
+		opts.CSVEscapeFormulas = exportCSVEscape
+
+		paths, err := expandDirs(args)
+		if err != nil {
+			return err
+		}
+		reports, loaded, err := loadValidReports(paths, 1)
+		if err != nil {
+			return err
+		}
+		if reports, _, err = filterByTime(reports, loaded, 1); err != nil {
+			return err
+		}
+		if exportAnonymize {
+			red, err := configRedaction()
+			if err != nil {
+				return err
+			}
+			reports = analysis.Anonymize(reports, red)
+		}
+		reports = format.GroupReports(reports, opts.GroupBy)
+
+		data, err := renderReports(name, reports, opts)
+		if err != nil {
+			return err
+		}
+		return writeOutput(data, exportOutput)
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "markdown", "Output format: markdown, csv, json, html, ascii, summary, xlsx or svg (inferred from the --output extension when not given)")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file (default stdout; required for xlsx)")
+	exportCmd.Flags().StringVar(&exportMetrics, "metrics", "", "Comma-separated metric keys to include, in order")
+	exportCmd.Flags().StringVar(&exportSortBy, "sort-metrics", format.SortRegistry, "Metric row order: registry, spread or alpha")
+	exportCmd.Flags().StringVar(&exportGroupBy, "group-by", format.GroupByProduct, "Label and group the columns by product, persona or agent_model")
+	exportCmd.Flags().IntVar(&exportSplit, "split-every", 0, "Split the markdown table into tables of at most this many products (0 = one table)")
+	exportCmd.Flags().StringVar(&exportCSVDelim, "csv-delimiter", ",", "CSV cell separator: one character such as , ; | or tab")
+	exportCmd.Flags().StringVar(&exportCSVDecimal, "csv-decimal", ".", "CSV decimal separator: . or ,")
+	exportCmd.Flags().BoolVar(&exportCSVEscape, "csv-escape-formulas", false, "Prefix CSV text cells starting with = + - @ with ' so spreadsheets don't run them as formulas")
+	exportCmd.Flags().BoolVar(&exportTranspose, "transpose", false, "Render products as rows and metrics as columns (markdown/csv)")
+	exportCmd.Flags().BoolVar(&exportFooter, "footer", false, "Add an Overall row with each product's metrics won and average normalized score")
+	exportCmd.Flags().BoolVar(&exportAnonymize, "anonymize", false, "Redact operators, personas, agent models and URLs first (see the config file's redact list)")
+	addTimeWindowFlags(exportCmd)
+	rootCmd.AddCommand(exportCmd)
+}
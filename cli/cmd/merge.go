@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"uxbench/cli/analysis"
+	"uxbench/cli/loader"
+	"uxbench/cli/logging"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	mergeOutput       string
+	mergeDropOutliers bool
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge [file|dir]...",
+	Short: "Average repeated runs of one product/task into a single report",
+	Long: `Load several recordings of the same product and task and write a new report
+whose metrics are the mean of the runs. Directories expand to their .json files.
+
+With 3 or more runs, any run whose value for a metric is more than 2 standard
+deviations from the other runs' mean (and at least 10% off it) is reported as
+an outlier; --drop-outliers leaves those runs out of the average.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paths, err := loader.ExpandPaths(args)
+		if err != nil {
+			return err
+		}
+		runs, err := loadReports(paths)
+		if err != nil {
+			return err
+		}
+		// A run saved twice would count double in the average
+		paths, runs, _ = dedupeReports(paths, runs)
+		if len(runs) < 2 {
+			return fmt.Errorf("need at least 2 runs to merge, got %d", len(runs))
+		}
+
+		outliers := analysis.FindOutliers(runs)
+		for _, o := range outliers {
+			logging.Infof("Outlier: %s — %s %s (other runs average %s)\n",
+				paths[o.Run], o.Metric.Label, o.Metric.FormatValue(o.Value), o.Metric.FormatValue(o.OthersMean))
+		}
+		if mergeDropOutliers && len(outliers) > 0 {
+			kept, dropped := analysis.DropOutliers(runs, outliers)
+			if len(kept) < 2 {
+				return fmt.Errorf("only %d run(s) left after dropping outliers; need at least 2", len(kept))
+			}
+			for _, i := range dropped {
+				logging.Infof("Dropped %s", paths[i])
+			}
+			runs = kept
+		}
+
+		merged, err := analysis.MergeReports(runs)
+		if err != nil {
+			return err
+		}
+
+		out := mergeOutput
+		if out == "" {
+			out = fmt.Sprintf("%s_%s_AVG_%druns.json", fileSlug(merged.Metadata.Product), fileSlug(merged.Metadata.Task), len(runs))
+		}
+		data, err := json.MarshalIndent(merged, "", "    ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(out, append(data, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", out, err)
+		}
+		logging.Infof("Merged %d runs into %s", len(runs), out)
+		return nil
+	},
+}
+
+// fileSlug makes s safe for a filename, e.g. "Create Customer" -> "create-customer".
+func fileSlug(s string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			dash = false
+			sb.WriteRune(r)
+		} else {
+			dash = true
+		}
+	}
+	return sb.String()
+}
+
+func init() {
+	mergeCmd.Flags().StringVarP(&mergeOutput, "output", "o", "", "Output file (default <product>_<task>_AVG_<n>runs.json)")
+	mergeCmd.Flags().BoolVar(&mergeDropOutliers, "drop-outliers", false, "Exclude runs flagged as outliers before averaging")
+	rootCmd.AddCommand(mergeCmd)
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"uxbench/cli/analysis"
+	"uxbench/cli/format"
+	"uxbench/cli/loader"
+	"uxbench/cli/logging"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	rankBy     string
+	rankFormat string
+	rankDryRun bool
+	rankAsc    bool
+	rankDesc   bool
+)
+
+// rankKeyMetrics are shown alongside the ranking metric in the leaderboard.
+var rankKeyMetrics = []string{"Composite Score", "Total Clicks", "Time on Task (ms)", "Fitts Avg ID"}
+
+var rankCmd = &cobra.Command{
+	Use:   "rank [file|dir] ...",
+	Short: "Rank many recordings on a leaderboard",
+	Long: `Load every recording (directories are expanded to their .json files) and
+rank them best-first by composite score, or by any metric with --by.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		by, order, err := analysis.ParseRankBy(rankBy)
+		if err != nil {
+			return err
+		}
+		flagOrder := ""
+		switch {
+		case rankAsc:
+			flagOrder = analysis.OrderAsc
+		case rankDesc:
+			flagOrder = analysis.OrderDesc
+		}
+		if order != "" && flagOrder != "" && order != flagOrder {
+			return fmt.Errorf("--by %s conflicts with --%s", rankBy, flagOrder)
+		}
+		if order == "" {
+			order = flagOrder
+		}
+		by = analysis.WithOrder(by, order)
+
+		paths, err := loader.ExpandPaths(args)
+		if err != nil {
+			return err
+		}
+		if len(paths) == 0 {
+			return fmt.Errorf("no .json reports found")
+		}
+		if rankDryRun {
+			return printDryRun(paths)
+		}
+		reports, loaded, err := loadValidReports(paths, 1)
+		if err != nil {
+			return err
+		}
+		if reports, _, err = filterByTime(reports, loaded, 1); err != nil {
+			return err
+		}
+
+		columns := []format.MetricDef{by}
+		for _, label := range rankKeyMetrics {
+			if def, _ := analysis.FindMetric(label); def.Label != by.Label {
+				columns = append(columns, def)
+			}
+		}
+
+		entries := analysis.Rank(reports, by)
+		if skipped := len(reports) - len(entries); skipped > 0 {
+			logging.Warnf("%d report(s) have no %s and are not ranked", skipped, by.Label)
+		}
+		switch rankFormat {
+		case "text":
+			printLeaderboard(entries, columns)
+		case "json":
+			type row struct {
+				analysis.RankEntry
+				Metrics map[string]*float64 `json:"metrics"`
+			}
+			rows := make([]row, len(entries))
+			for i, e := range entries {
+				rows[i] = row{RankEntry: e, Metrics: map[string]*float64{}}
+				for _, def := range columns {
+					rows[i].Metrics[def.Key] = format.OptionalValue(def.Value(e.Report))
+				}
+			}
+			out, err := json.MarshalIndent(struct {
+				By      string `json:"by"`
+				Order   string `json:"order"`
+				Entries []row  `json:"entries"`
+			}{by.Key, analysis.Order(by), rows}, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+		case "csv":
+			w := csv.NewWriter(os.Stdout)
+			header := []string{"Rank", "Product", "Task"}
+			for _, def := range columns {
+				header = append(header, def.Key)
+			}
+			w.Write(header)
+			for _, e := range entries {
+				rec := []string{fmt.Sprint(e.Rank), e.Product, e.Task}
+				for _, def := range columns {
+					rec = append(rec, def.FormatPlain(def.Value(e.Report)))
+				}
+				w.Write(rec)
+			}
+			w.Flush()
+			return w.Error()
+		default:
+			return fmt.Errorf("unknown format %q (expected text, json or csv)", rankFormat)
+		}
+		return nil
+	},
+}
+
+func printLeaderboard(entries []analysis.RankEntry, columns []format.MetricDef) {
+	productWidth := len("Product")
+	for _, e := range entries {
+		if len(e.Product) > productWidth {
+			productWidth = len(e.Product)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%4s  %-*s", "#", productWidth, "Product"))
+	for _, def := range columns {
+		sb.WriteString(fmt.Sprintf("  %18s", def.Label))
+	}
+	sb.WriteString("\n")
+
+	for i, e := range entries {
+		rank := fmt.Sprint(e.Rank)
+		if (i > 0 && entries[i-1].Rank == e.Rank) || (i+1 < len(entries) && entries[i+1].Rank == e.Rank) {
+			rank = "=" + rank // shared rank
+		}
+		sb.WriteString(fmt.Sprintf("%4s  %-*s", rank, productWidth, e.Product))
+		for _, def := range columns {
+			sb.WriteString(fmt.Sprintf("  %18s", def.FormatValue(def.Value(e.Report))))
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Print(sb.String())
+}
+
+func init() {
+	rankCmd.Flags().StringVar(&rankBy, "by", "Composite Score", "Metric to rank by (label or snake_case key), optionally suffixed :asc or :desc")
+	rankCmd.Flags().BoolVar(&rankAsc, "asc", false, "Rank lowest values first, whatever the metric's usual direction")
+	rankCmd.Flags().BoolVar(&rankDesc, "desc", false, "Rank highest values first, whatever the metric's usual direction")
+	rankCmd.MarkFlagsMutuallyExclusive("asc", "desc")
+	rankCmd.Flags().StringVarP(&rankFormat, "format", "f", "text", "Output format: text, json or csv")
+	rankCmd.Flags().BoolVar(&rankDryRun, "dry-run", false, "List the files that would be loaded, with product and task and any that would be skipped, and exit")
+	addTimeWindowFlags(rankCmd)
+	rootCmd.AddCommand(rankCmd)
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+	"uxbench/cli/analysis"
+	"uxbench/cli/format"
+	"uxbench/cli/logging"
+	"uxbench/schema"
+)
+
+// fileFormats are the non-interactive output formats shared by compare and
+// export ("md" is accepted as an alias for markdown).
+var fileFormats = []string{"markdown", "csv", "json", "html", "ascii", "summary", "xlsx", "svg"}
+
+// formatOptions validates the rendering flags shared by compare and export and
+// turns them into format.Options. metrics is a comma-separated key list.
+func formatOptions(metrics, sortBy, groupBy string, transpose, footer bool, splitEvery int) (format.Options, error) {
+	if !slices.Contains(format.SortMetricsModes, sortBy) {
+		return format.Options{}, fmt.Errorf("invalid --sort-metrics %q (expected %s)", sortBy, strings.Join(format.SortMetricsModes, ", "))
+	}
+	if !slices.Contains(format.GroupByModes, groupBy) {
+		return format.Options{}, fmt.Errorf("invalid --group-by %q (expected %s)", groupBy, strings.Join(format.GroupByModes, ", "))
+	}
+	if splitEvery < 0 {
+		return format.Options{}, fmt.Errorf("--split-every must be 0 (one table) or more, got %d", splitEvery)
+	}
+	opts := format.Options{Transpose: transpose, SortMetrics: sortBy, Footer: footer, GroupBy: groupBy, SplitEvery: splitEvery}
+	if metrics != "" {
+		defs, err := analysis.SelectMetrics(metrics)
+		if err != nil {
+			return format.Options{}, err
+		}
+		opts.Metrics = defs
+	}
+	return opts, nil
+}
+
+// withCSVSeparators validates --csv-delimiter and --csv-decimal into opts.
+// The delimiter is one character ("tab" or "\t" for a tab); the decimal
+// separator is "." or ",", and the two must differ.
+func withCSVSeparators(opts format.Options, delimiter, decimal string) (format.Options, error) {
+	if delimiter == "tab" || delimiter == `\t` {
+		delimiter = "\t"
+	}
+	d := []rune(delimiter)
+	if len(d) != 1 || d[0] == '"' || d[0] == '\r' || d[0] == '\n' || d[0] == utf8.RuneError {
+		return opts, fmt.Errorf("invalid --csv-delimiter %q (expected one character such as , ; | or tab)", delimiter)
+	}
+	if decimal != "." && decimal != "," {
+		return opts, fmt.Errorf("invalid --csv-decimal %q (expected . or ,)", decimal)
+	}
+	if delimiter == decimal {
+		return opts, fmt.Errorf("--csv-delimiter and --csv-decimal are both %q; use --csv-delimiter \";\" with a decimal comma", decimal)
+	}
+	opts.CSVDelimiter, opts.CSVDecimal = d[0], rune(decimal[0])
+	return opts, nil
+}
+
+// renderReports renders reports in one of fileFormats.
+func renderReports(name string, reports []*schema.BenchmarkReport, opts format.Options) ([]byte, error) {
+	switch name {
+	case "markdown", "md":
+		return []byte(format.GenerateMarkdownTableWithOptions(reports, opts)), nil
+	case "csv":
+		return []byte(format.GenerateCSVWithOptions(reports, opts)), nil
+	case "json":
+		return []byte(format.GenerateJSONWithOptions(reports, opts)), nil
+	case "html":
+		return []byte(format.GenerateHTMLWithOptions(reports, opts)), nil
+	case "ascii":
+		return []byte(format.GenerateASCIITableWithOptions(reports, opts)), nil
+	case "summary":
+		return []byte(format.GenerateSummaryLineWithOptions(reports, opts)), nil
+	case "svg":
+		return []byte(format.GenerateRadarSVGWithOptions(reports, opts)), nil
+	case "xlsx":
+		return format.GenerateXLSXWithOptions(reports, opts)
+	}
+	return nil, fmt.Errorf("unknown format %q (expected %s)", name, strings.Join(fileFormats, ", "))
+}
+
+// formatForPath guesses the output format from a file extension, returning ""
+// when the extension isn't one of fileFormats.
+func formatForPath(path string) string {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	switch ext {
+	case "md":
+		return "markdown"
+	case "htm":
+		return "html"
+	case "txt":
+		return "ascii"
+	}
+	if slices.Contains(fileFormats, ext) {
+		return ext
+	}
+	return ""
+}
+
+// writeOutput writes data to path, or to stdout when path is empty.
+func writeOutput(data []byte, path string) error {
+	if path == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	logging.Infof("Saved to %s", path)
+	return nil
+}
@@ -1,45 +1,178 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"time"
+	"uxbench/cli/analysis"
+	"uxbench/cli/config"
+	"uxbench/cli/format"
+	"uxbench/cli/loader"
+	"uxbench/cli/logging"
 	"uxbench/cli/tui"
+	"uxbench/schema"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 )
 
+var (
+	httpTimeout time.Duration
+	precision   int
+	quiet       bool
+	verbose     bool
+	topN        int
+	noDedup     bool
+)
+
+// tuiProgramOptions run the picker and results views full-screen with mouse
+// reporting: click rows to select files or sort by a metric. The alternate
+// screen keeps mouse rows aligned with the view.
+var tuiProgramOptions = []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}
+
+// cfg holds the config file defaults, loaded before any command runs.
+var cfg = &config.Config{Weights: analysis.DefaultWeights}
+
 var rootCmd = &cobra.Command{
 	Use:   "uxbench",
 	Short: "UX Bench - Analyze and compare interaction efficiency",
 	Long: `UX Bench is a CLI tool for analyzing benchmark data collected
 by the UX Bench Recorder extension. It allows for head-to-head comparisons
 of product efficiency.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		switch {
+		case quiet:
+			logging.SetLevel(logging.Quiet)
+		case verbose:
+			logging.SetLevel(logging.Verbose)
+		}
+		if err := applyColor(colorMode); err != nil {
+			return err
+		}
+		// Let `config init` repair a broken config file
+		if cmd.Parent() == configCmd {
+			return nil
+		}
+		c, err := config.Load()
+		if err != nil {
+			return err
+		}
+		cfg = c
+		if err := analysis.RegisterCustomMetrics(cfg.Metrics); err != nil {
+			return fmt.Errorf("%s: %w", cfg.Path(), err)
+		}
+		loader.HTTPTimeout = httpTimeout
+		if cfg.Decimals > 0 {
+			format.Decimals = cfg.Decimals
+		}
+		if cmd.Flags().Changed("precision") {
+			if precision < 0 || precision > 10 {
+				return fmt.Errorf("--precision must be between 0 and 10, got %d", precision)
+			}
+			format.Decimals = precision
+		}
+		if topN < 0 {
+			return fmt.Errorf("--top must be 0 (no limit) or more, got %d", topN)
+		}
+		format.TopN = topN
+		tui.PickerRoot = cfg.PickerRoot
+		return nil
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if len(args) == 0 {
-			// Interactive Menu
-			p := tea.NewProgram(tui.NewMenuModel())
-			m, err := p.Run()
-			if err != nil {
+		if len(args) != 0 {
+			return cmd.Help()
+		}
+
+		// Interactive Menu
+		p := tea.NewProgram(tui.NewMenuModel())
+		m, err := p.Run()
+		if err != nil {
+			return err
+		}
+		menu := m.(tui.MenuModel)
+
+		switch menu.Selected {
+		case tui.MenuCompare:
+			// compareCmd.RunE handles 0 args as the interactive picker -> results flow.
+			return compareCmd.RunE(compareCmd, []string{})
+		case tui.MenuSummarize:
+			paths, err := pickFiles(1)
+			if err != nil || len(paths) == 0 {
+				return err
+			}
+			// Like the summary command, use the full loader rather than the
+			// metrics-only one comparisons use
+			for i, p := range paths {
+				r, err := loader.LoadReport(p)
+				if err != nil {
+					return fmt.Errorf("failed to load %s: %w", p, err)
+				}
+				if i > 0 {
+					fmt.Println()
+				}
+				fmt.Print(summaryText(r))
+			}
+		case tui.MenuExport:
+			paths, err := pickFiles(2)
+			if err != nil || len(paths) == 0 {
 				return err
 			}
-			menu := m.(tui.MenuModel)
-			
-			if menu.Selected == "Compare Recordings" {
-				// Run compare command interactively
-				// We can't easily execute a sub-command's RunE directly if it relies on its own flags/args parsing
-				// usually, but we designed compareCmd.RunE to handle 0 args as interactive.
-				// So we can just call it with empty args.
-				return compareCmd.RunE(compareCmd, []string{})
+			reports, _, err := loadValidReports(paths, 2)
+			if err != nil {
+				return err
 			}
+			return exportReports(reports)
 		}
-		return nil // Or print help? If nothing selected/exit
+		// MenuQuit / MenuNone: nothing to do
+		return nil
 	},
 }
 
-func Execute() error {
-	return rootCmd.Execute()
+// pickFiles runs the standalone file picker and returns the confirmed selection,
+// or nil if the user quit without confirming.
+func pickFiles(min int) ([]string, error) {
+	picker := tui.NewModel()
+	picker.MinSelected = min
+	m, err := tea.NewProgram(picker, tuiProgramOptions...).Run()
+	if err != nil {
+		return nil, err
+	}
+	result := m.(tui.Model)
+	if !result.Confirmed() {
+		return nil, nil
+	}
+	return result.SelectedPaths, nil
+}
+
+// exportReports writes the Markdown and CSV comparison files to the working directory.
+func exportReports(reports []*schema.BenchmarkReport) error {
+	outputs := []struct {
+		filename string
+		content  string
+	}{
+		{"comparison_report.md", format.GenerateMarkdownTable(reports)},
+		{"comparison_report.csv", format.GenerateCSV(reports)},
+	}
+	for _, o := range outputs {
+		if err := os.WriteFile(o.filename, []byte(o.content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", o.filename, err)
+		}
+		logging.Infof("Saved to %s", o.filename)
+	}
+	return nil
 }
 
 func init() {
-	// Add subcommands here
-	// rootCmd.AddCommand(compareCmd)
+	rootCmd.PersistentFlags().DurationVar(&httpTimeout, "timeout", 30*time.Second, "Timeout for each report fetched from an http(s) URL")
+	rootCmd.PersistentFlags().IntVar(&precision, "precision", 2, "Decimal places for fractional metrics (percentages get one fewer, counts none; overrides the config file)")
+	rootCmd.PersistentFlags().IntVar(&topN, "top", format.TopN, "Items shown per detail list (hardest targets, free-text fields, click details, pages, idle gaps), with \"+K more\" for the rest; 0 shows all")
+	rootCmd.PersistentFlags().BoolVar(&noDedup, "no-dedup", false, "Keep reports whose content duplicates an earlier file (compare, rank, merge)")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Only print the requested output and errors (no warnings or notices on stderr)")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Print extra detail on stderr, such as per-file load timings")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", "auto", "Color output: auto (only on a terminal), always or never; NO_COLOR also disables it")
+}
+
+func Execute() error {
+	return rootCmd.Execute()
 }
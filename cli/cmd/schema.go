@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"uxbench/cli/jsonschema"
+	"uxbench/cli/logging"
+	"uxbench/schema"
+
+	"github.com/spf13/cobra"
+)
+
+var schemaOutput string
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema for benchmark reports",
+	Long: `Print a JSON Schema (draft-07) generated from the report types the loader
+decodes into, for recorder developers to validate their output against.
+Only what the analyzer can't do without is required (schema_version, source,
+metadata.product, metadata.timestamp, metrics.composite_score and their
+parent objects); everything else may be left out, and pointer fields may
+also be null.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s := jsonschema.Generate(schema.BenchmarkReport{}, "UX Benchmark Report")
+		data, err := jsonschema.Marshal(s)
+		if err != nil {
+			return err
+		}
+
+		if schemaOutput == "" {
+			fmt.Print(string(data))
+			return nil
+		}
+		if err := os.WriteFile(schemaOutput, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", schemaOutput, err)
+		}
+		logging.Infof("Saved to %s", schemaOutput)
+		return nil
+	},
+}
+
+func init() {
+	schemaCmd.Flags().StringVarP(&schemaOutput, "output", "o", "", "Write the schema to a file instead of stdout")
+	rootCmd.AddCommand(schemaCmd)
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"uxbench/cli/format"
+	"uxbench/cli/loader"
+	"uxbench/cli/logging"
+	"uxbench/schema"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr string
+	serveDir  string
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve comparisons of a report directory over HTTP",
+	Long: `Start a small HTTP server for a shared dashboard. Reports are loaded from
+--dir (or the config file's serve_dir) on every request, so new recordings show
+up without a restart. Endpoints:
+
+  /                                  list the reports, with a form to compare them
+  /compare?files=a.json,b.json       HTML comparison
+  /api/compare?files=a.json,b.json   JSON comparison
+  /api/reports                       JSON list of the report files
+
+File names are relative to the directory and can't leave it. /compare and
+/api/compare also accept metrics=key,key and group_by=persona.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !cmd.Flags().Changed("dir") && cfg.ServeDir != "" {
+			serveDir = cfg.ServeDir
+		}
+		if info, err := os.Stat(serveDir); err != nil || !info.IsDir() {
+			return fmt.Errorf("--dir %s is not a directory", serveDir)
+		}
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /{$}", serveIndex)
+		mux.HandleFunc("GET /compare", serveCompare(func(w http.ResponseWriter, reports []*schema.BenchmarkReport, opts format.Options) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprint(w, format.GenerateHTMLWithOptions(reports, opts))
+		}))
+		mux.HandleFunc("GET /api/compare", serveCompare(func(w http.ResponseWriter, reports []*schema.BenchmarkReport, opts format.Options) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, format.GenerateJSONWithOptions(reports, opts))
+		}))
+		mux.HandleFunc("GET /api/reports", serveReportList)
+
+		logging.Infof("Serving %s on http://%s", serveDir, serveAddr)
+		return http.ListenAndServe(serveAddr, mux)
+	},
+}
+
+// servedReports lists the report files in serveDir by name (see loader.ExpandPaths).
+func servedReports() ([]string, error) {
+	paths, err := loader.ExpandPaths([]string{serveDir})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(paths))
+	for i, p := range paths {
+		names[i] = filepath.Base(p)
+	}
+	return names, nil
+}
+
+// servedPath resolves a requested file name inside serveDir, refusing
+// absolute paths and anything that climbs out of it.
+func servedPath(name string) (string, error) {
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("invalid file name %q", name)
+	}
+	return filepath.Join(serveDir, name), nil
+}
+
+// serveCompare parses files, metrics and group_by, loads the reports and
+// hands them to render. Bad requests get a 400 with the reason.
+func serveCompare(render func(http.ResponseWriter, []*schema.BenchmarkReport, format.Options)) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		q := req.URL.Query()
+		var paths []string
+		for _, name := range strings.Split(q.Get("files"), ",") {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			p, err := servedPath(name)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			paths = append(paths, p)
+		}
+		if len(paths) < 2 {
+			http.Error(w, "need at least two reports: ?files=a.json,b.json", http.StatusBadRequest)
+			return
+		}
+
+		groupBy := q.Get("group_by")
+		if groupBy == "" {
+			groupBy = format.GroupByProduct
+		}
+		opts, err := formatOptions(q.Get("metrics"), format.SortRegistry, groupBy, false, false, 0)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		reports, err := loadReports(paths)
+		if err != nil {
+			// Strip the server's directory from the message
+			http.Error(w, strings.ReplaceAll(err.Error(), filepath.Clean(serveDir)+string(filepath.Separator), ""), http.StatusBadRequest)
+			return
+		}
+		logging.Debugf("%s %s: %d report(s)", req.Method, req.URL, len(reports))
+		render(w, format.GroupReports(reports, opts.GroupBy), opts)
+	}
+}
+
+func serveReportList(w http.ResponseWriter, req *http.Request) {
+	names, err := servedReports()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Reports []string `json:"reports"`
+	}{append([]string{}, names...)})
+}
+
+// serveIndex lists the reports with checkboxes that submit to /compare.
+func serveIndex(w http.ResponseWriter, req *http.Request) {
+	names, err := servedReports()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>UX Bench</title>\n")
+	sb.WriteString("<style>body{font-family:system-ui,sans-serif;margin:2em;color:#222}label{display:block;margin:4px 0}</style>\n")
+	sb.WriteString("</head>\n<body>\n<h1>UX Bench</h1>\n")
+	if len(names) == 0 {
+		sb.WriteString("<p>No reports found.</p>\n")
+	} else {
+		// The script joins the checked names into the files parameter
+		sb.WriteString("<form action=\"compare\" onsubmit=\"this.files.value=[...this.querySelectorAll('input:checked')].map(c=>c.value).join(',')\">\n")
+		sb.WriteString("<input type=\"hidden\" name=\"files\">\n")
+		for _, n := range names {
+			e := html.EscapeString(n)
+			sb.WriteString(fmt.Sprintf("<label><input type=\"checkbox\" value=\"%s\"> %s</label>\n", e, e))
+		}
+		sb.WriteString("<p><button>Compare</button></p>\n</form>\n")
+	}
+	sb.WriteString("</body>\n</html>\n")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, sb.String())
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "localhost:8080", "Address to listen on (use :8080 to accept connections from other machines)")
+	serveCmd.Flags().StringVar(&serveDir, "dir", ".", "Directory to load reports from (overrides the config file's serve_dir)")
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"uxbench/cli/analysis"
+	"uxbench/cli/format"
+	"uxbench/cli/loader"
+	"uxbench/schema"
+
+	"github.com/spf13/cobra"
+)
+
+var summaryCmd = &cobra.Command{
+	Use:   "summary [file]",
+	Short: "Summarize a single benchmark recording",
+	Long: `Print the metrics, composite score breakdown and longest idle gaps of a
+single recording.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r, err := loader.LoadReport(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", args[0], err)
+		}
+		fmt.Print(summaryText(r))
+		return nil
+	},
+}
+
+// summaryText is the summary followed by the composite breakdown under the
+// configured weights.
+func summaryText(r *schema.BenchmarkReport) string {
+	return format.GenerateSummary(r) + "\n" + breakdownText(analysis.BreakdownComposite(r, cfg.Weights))
+}
+
+func breakdownText(b analysis.CompositeBreakdown) string {
+	var sb strings.Builder
+	sb.WriteString("Composite Breakdown\n")
+	sb.WriteString(fmt.Sprintf("  %-20s %12s %8s %12s\n", "Metric", "Value", "Weight", "Contribution"))
+	for _, t := range b.Terms {
+		sb.WriteString(fmt.Sprintf("  %-20s %12s %8.3g %12s\n", t.Label, format.Fixed(t.Value), t.Weight, format.Fixed(t.Contribution)))
+	}
+	sb.WriteString(fmt.Sprintf("  %-20s %12s %8s %12s\n", "Total", "", "", format.Fixed(b.Recomputed)))
+	if b.Discrepancy() {
+		sb.WriteString(fmt.Sprintf("  ! Report says %s; recomputed %s with the current weights\n", format.Fixed(b.Reported), format.Fixed(b.Recomputed)))
+	}
+	return sb.String()
+}
+
+func init() {
+	rootCmd.AddCommand(summaryCmd)
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"uxbench/cli/loader"
+	"uxbench/cli/logging"
+	"uxbench/cli/tui"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+var (
+	timelineType       string
+	timelineMaxActions int
+)
+
+var timelineCmd = &cobra.Command{
+	Use:   "timeline [file]",
+	Short: "Browse the action log of a recording as a timeline",
+	Long: `Render the action log of a single recording as a scrollable timeline.
+Idle gaps are shown inline where they occurred.
+
+The action log is streamed, with progress on stderr for long logs; use
+--max-actions to keep only the first entries of a huge one.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if timelineMaxActions < 0 {
+			return fmt.Errorf("--max-actions must be 0 or more, got %d", timelineMaxActions)
+		}
+		r, total, err := loader.LoadReportWith(args[0], loader.LoadOptions{
+			MaxActions: timelineMaxActions,
+			Progress: func(n int) {
+				logging.Infof("Reading the action log of %s: %d actions so far", args[0], n)
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", args[0], err)
+		}
+		if total > len(r.ActionLog) {
+			logging.Infof("Showing the first %d of %d actions (--max-actions)", len(r.ActionLog), total)
+		}
+
+		p := tea.NewProgram(tui.NewTimelineModel(r, total, timelineType), tea.WithAltScreen())
+		if _, err := p.Run(); err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+func init() {
+	timelineCmd.Flags().StringVarP(&timelineType, "type", "t", "", "Only show actions of this type (e.g. click)")
+	timelineCmd.Flags().IntVar(&timelineMaxActions, "max-actions", 0, "Only load the first N action log entries (0 = all)")
+	rootCmd.AddCommand(timelineCmd)
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+	"uxbench/cli/analysis"
+	"uxbench/cli/logging"
+	"uxbench/schema"
+
+	"github.com/spf13/cobra"
+)
+
+var timeSince, timeUntil string
+
+// addTimeWindowFlags adds --since and --until to a command that loads
+// batches of reports; see filterByTime.
+func addTimeWindowFlags(c *cobra.Command) {
+	c.Flags().StringVar(&timeSince, "since", "", "Only use reports recorded at or after this time: an age (7d, 2w, 36h), a date (2024-05-01) or an RFC 3339 timestamp")
+	c.Flags().StringVar(&timeUntil, "until", "", "Only use reports recorded at or before this time (same forms as --since)")
+}
+
+// timeWindow parses --since and --until.
+func timeWindow() (analysis.TimeWindow, error) {
+	var w analysis.TimeWindow
+	now := time.Now()
+	var err error
+	if timeSince != "" {
+		if w.Since, err = analysis.ParseTimeBound(timeSince, now); err != nil {
+			return w, fmt.Errorf("--since: %w", err)
+		}
+	}
+	if timeUntil != "" {
+		if w.Until, err = analysis.ParseTimeBound(timeUntil, now); err != nil {
+			return w, fmt.Errorf("--until: %w", err)
+		}
+	}
+	if !w.Since.IsZero() && !w.Until.IsZero() && w.Until.Before(w.Since) {
+		return w, fmt.Errorf("--until %s is before --since %s", timeUntil, timeSince)
+	}
+	return w, nil
+}
+
+// filterByTime drops the reports recorded outside --since/--until (and those
+// without a timestamp when either is set), logging how many, alongside their
+// paths. It fails when fewer than min reports are left.
+func filterByTime(reports []*schema.BenchmarkReport, paths []string, min int) ([]*schema.BenchmarkReport, []string, error) {
+	reports, paths, _, err := filterByTimeSkipped(reports, paths, min)
+	return reports, paths, err
+}
+
+// filterByTimeSkipped is filterByTime that also returns the files it dropped.
+func filterByTimeSkipped(reports []*schema.BenchmarkReport, paths []string, min int) ([]*schema.BenchmarkReport, []string, []skippedFile, error) {
+	w, err := timeWindow()
+	if err != nil || w.IsZero() {
+		return reports, paths, nil, err
+	}
+	kept, outside, undated := w.Filter(reports)
+	if outside > 0 {
+		logging.Infof("Skipped %d report(s) recorded outside --since/--until", outside)
+	}
+	if undated > 0 {
+		logging.Infof("Skipped %d report(s) with no timestamp", undated)
+	}
+	var keptReports []*schema.BenchmarkReport
+	var keptPaths []string
+	var skipped []skippedFile
+	next := 0
+	for i, r := range reports {
+		if next < len(kept) && kept[next] == i {
+			keptReports = append(keptReports, r)
+			keptPaths = append(keptPaths, paths[i])
+			next++
+			continue
+		}
+		reason := "recorded outside --since/--until"
+		if r.Metadata.Timestamp.IsZero() {
+			reason = "no timestamp to check against --since/--until"
+		}
+		skipped = append(skipped, skippedFile{Path: paths[i], Reason: reason})
+	}
+	if len(keptReports) < min {
+		return nil, nil, nil, fmt.Errorf("only %d of %d report(s) were recorded within --since/--until (need at least %d)", len(keptReports), len(reports), min)
+	}
+	return keptReports, keptPaths, skipped, nil
+}
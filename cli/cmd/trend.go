@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"uxbench/cli/analysis"
+	"uxbench/cli/format"
+	"uxbench/cli/loader"
+	"uxbench/cli/logging"
+	"uxbench/schema"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
+)
+
+var (
+	trendProduct     string
+	trendTask        string
+	trendMetrics     string
+	trendDetail      bool
+	trendNoSparkline bool
+	trendFormat      string
+)
+
+var trendCmd = &cobra.Command{
+	Use:   "trend [file|dir] ...",
+	Short: "Show how one product's metrics changed over a series of recordings",
+	Long: `Load repeated recordings of one product and task (directories are expanded
+to their .json files), order them by when they were recorded, and print each
+metric's value per run, the change from the first run to the last, and whether
+it is trending up or down overall (from a least-squares fit, so one outlier run
+doesn't decide it), with a sparkline.
+
+When the files cover several products or tasks, pick one with --product and
+--task. Runs recorded at the same instant are ordered by recording name, then
+file path.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paths, err := loader.ExpandPaths(args)
+		if err != nil {
+			return err
+		}
+		if len(paths) == 0 {
+			return fmt.Errorf("no .json reports found")
+		}
+		reports, loaded, err := loadValidReports(paths, 2)
+		if err != nil {
+			return err
+		}
+		if reports, loaded, err = filterByTime(reports, loaded, 2); err != nil {
+			return err
+		}
+		runs, files, err := selectSeries(reports, loaded)
+		if err != nil {
+			return err
+		}
+		analysis.SortRuns(runs, files)
+
+		var defs []format.MetricDef
+		if trendMetrics != "" {
+			if defs, err = analysis.SelectMetrics(trendMetrics); err != nil {
+				return err
+			}
+		} else {
+			for _, g := range format.GroupedMetrics(trendDetail) {
+				defs = append(defs, g.Metrics...)
+			}
+		}
+		trends := analysis.Trends(runs, defs)
+		if skipped := len(defs) - len(trends); skipped > 0 {
+			logging.Infof("Skipping %d metric(s) none of the runs have", skipped)
+		}
+
+		switch trendFormat {
+		case "text":
+			fmt.Print(trendText(runs, trends))
+		case "json":
+			return printTrendJSON(runs, files, trends)
+		case "csv":
+			w := csv.NewWriter(os.Stdout)
+			header := []string{"metric"}
+			for _, r := range runs {
+				header = append(header, runDate(r, time.RFC3339))
+			}
+			w.Write(append(header, "change", "direction", "outcome"))
+			for _, t := range trends {
+				rec := []string{t.Metric.Key}
+				for _, v := range t.Values {
+					rec = append(rec, t.Metric.FormatPlain(v))
+				}
+				w.Write(append(rec, t.Metric.FormatPlain(t.Delta), t.Direction, t.Outcome()))
+			}
+			w.Flush()
+			return w.Error()
+		default:
+			return fmt.Errorf("unknown format %q (expected text, json or csv)", trendFormat)
+		}
+		return nil
+	},
+}
+
+// selectSeries keeps the reports matching --product and --task (ignoring case)
+// and checks they are runs of a single product and task.
+func selectSeries(reports []*schema.BenchmarkReport, paths []string) ([]*schema.BenchmarkReport, []string, error) {
+	matches := func(got, want string) bool {
+		return want == "" || strings.EqualFold(strings.TrimSpace(got), strings.TrimSpace(want))
+	}
+	var runs []*schema.BenchmarkReport
+	var files []string
+	seen := map[string]bool{}
+	var series []string
+	for i, r := range reports {
+		if !matches(r.Metadata.Product, trendProduct) || !matches(r.Metadata.Task, trendTask) {
+			continue
+		}
+		runs, files = append(runs, r), append(files, paths[i])
+		if k := baselineKey(r); !seen[k] {
+			seen[k] = true
+			series = append(series, productTask(r))
+		}
+	}
+	sort.Strings(series)
+
+	switch {
+	case len(runs) == 0:
+		var available []string
+		for _, r := range reports {
+			if p := productTask(r); !slices.Contains(available, p) {
+				available = append(available, p)
+			}
+		}
+		sort.Strings(available)
+		var filters []string
+		if trendProduct != "" {
+			filters = append(filters, fmt.Sprintf("--product %q", trendProduct))
+		}
+		if trendTask != "" {
+			filters = append(filters, fmt.Sprintf("--task %q", trendTask))
+		}
+		return nil, nil, fmt.Errorf("no reports match %s (loaded %s)", strings.Join(filters, " "), strings.Join(available, ", "))
+	case len(series) > 1:
+		return nil, nil, fmt.Errorf("the reports cover %d products/tasks (%s); choose one with --product and --task", len(series), strings.Join(series, ", "))
+	case len(runs) < 2:
+		return nil, nil, fmt.Errorf("need at least two runs of %s for a trend, got 1", series[0])
+	}
+	return runs, files, nil
+}
+
+// runDate formats a run's timestamp with layout, or "undated".
+func runDate(r *schema.BenchmarkReport, layout string) string {
+	if r.Metadata.Timestamp.IsZero() {
+		return "undated"
+	}
+	return r.Metadata.Timestamp.Format(layout)
+}
+
+// trendArrows mark each direction in the text output.
+var trendArrows = map[string]string{analysis.TrendUp: "↑", analysis.TrendDown: "↓", analysis.TrendFlat: "→"}
+
+// trendText renders one row per metric with a column per run (headed by its
+// date), the change from first to last, the overall direction and a sparkline.
+func trendText(runs []*schema.BenchmarkReport, trends []analysis.MetricTrend) string {
+	header := []string{"Metric"}
+	for _, r := range runs {
+		header = append(header, runDate(r, "2006-01-02"))
+	}
+	header = append(header, "Change", "Trend")
+	if !trendNoSparkline {
+		header = append(header, "")
+	}
+	rows := [][]string{header}
+	for _, t := range trends {
+		row := []string{t.Metric.Label + " " + t.Metric.Arrow()}
+		for _, v := range t.Values {
+			row = append(row, t.Metric.FormatValue(v))
+		}
+		change := t.Metric.FormatValue(t.Delta)
+		if !format.IsMissing(t.Delta) && t.Delta > 0 {
+			change = "+" + change
+		}
+		row = append(row, change, trendArrows[t.Direction]+" "+t.Outcome())
+		if !trendNoSparkline {
+			row = append(row, format.Sparkline(t.Values, t.Metric.HigherIsBetter))
+		}
+		rows = append(rows, row)
+	}
+
+	widths := make([]int, len(header))
+	for _, row := range rows {
+		for i, c := range row {
+			widths[i] = max(widths[i], runewidth.StringWidth(c))
+		}
+	}
+	var sb strings.Builder
+	first, last := runs[0], runs[len(runs)-1]
+	sb.WriteString(fmt.Sprintf("%s — %s: %d runs, %s to %s\n\n", first.Metadata.Product, first.Metadata.Task, len(runs), runDate(first, "2006-01-02"), runDate(last, "2006-01-02")))
+	for _, row := range rows {
+		var line strings.Builder
+		for i, c := range row {
+			pad := strings.Repeat(" ", widths[i]-runewidth.StringWidth(c))
+			switch {
+			case i == 0, i >= len(row)-2:
+				line.WriteString(c + pad) // labels, trend and sparkline read left to right
+			default:
+				line.WriteString(pad + c)
+			}
+			line.WriteString("  ")
+		}
+		sb.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+	return sb.String()
+}
+
+func printTrendJSON(runs []*schema.BenchmarkReport, files []string, trends []analysis.MetricTrend) error {
+	type run struct {
+		File          string    `json:"file"`
+		RecordingName string    `json:"recording_name"`
+		Timestamp     time.Time `json:"timestamp"`
+	}
+	type metric struct {
+		Key       string     `json:"key"`
+		Label     string     `json:"label"`
+		Values    []*float64 `json:"values"`
+		Change    *float64   `json:"change"`
+		Slope     *float64   `json:"slope"`
+		Direction string     `json:"direction"`
+		Outcome   string     `json:"outcome"`
+	}
+	out := struct {
+		Product string   `json:"product"`
+		Task    string   `json:"task"`
+		Runs    []run    `json:"runs"`
+		Metrics []metric `json:"metrics"`
+	}{Product: runs[0].Metadata.Product, Task: runs[0].Metadata.Task, Metrics: []metric{}}
+	for i, r := range runs {
+		out.Runs = append(out.Runs, run{File: files[i], RecordingName: r.Metadata.RecordingName, Timestamp: r.Metadata.Timestamp})
+	}
+	for _, t := range trends {
+		m := metric{Key: t.Metric.Key, Label: t.Metric.Label, Change: format.OptionalValue(t.Delta), Slope: format.OptionalValue(t.Slope), Direction: t.Direction, Outcome: t.Outcome()}
+		for _, v := range t.Values {
+			m.Values = append(m.Values, format.OptionalValue(v))
+		}
+		out.Metrics = append(out.Metrics, m)
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func init() {
+	trendCmd.Flags().StringVar(&trendProduct, "product", "", "Only use recordings of this product (case-insensitive)")
+	trendCmd.Flags().StringVar(&trendTask, "task", "", "Only use recordings of this task (case-insensitive)")
+	trendCmd.Flags().StringVar(&trendMetrics, "metrics", "", "Comma-separated metric keys to show, in order (default: the metrics the comparison table shows)")
+	trendCmd.Flags().BoolVar(&trendDetail, "detail", false, "Also include the detail-only metrics")
+	trendCmd.Flags().BoolVar(&trendNoSparkline, "no-sparkline", false, "Leave out the sparkline column")
+	trendCmd.Flags().StringVarP(&trendFormat, "format", "f", "text", "Output format: text, json or csv")
+	addTimeWindowFlags(trendCmd)
+	rootCmd.AddCommand(trendCmd)
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// Build metadata, injected at build time:
+//
+//	go build -ldflags "-X uxbench/cli/cmd.Version=1.2.0 -X uxbench/cli/cmd.Commit=$(git rev-parse --short HEAD) -X uxbench/cli/cmd.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Version   = "dev"
+	Commit    = "none"
+	BuildDate = "unknown"
+)
+
+func versionString() string {
+	return fmt.Sprintf("uxbench %s (commit %s, built %s)", Version, Commit, BuildDate)
+}
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the uxbench build version",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println(versionString())
+	},
+}
+
+func init() {
+	rootCmd.Version = versionString()
+	rootCmd.SetVersionTemplate("{{.Version}}\n")
+	rootCmd.AddCommand(versionCmd)
+}
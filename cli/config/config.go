@@ -0,0 +1,106 @@
+// Package config reads user defaults for the CLI from a YAML file.
+// Command-line flags always take precedence over anything set here.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"uxbench/cli/analysis"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LocalFile is looked up in the working directory before the user config file.
+const LocalFile = ".uxbench.yaml"
+
+// Config holds the defaults a config file can set. Zero values mean "not set".
+type Config struct {
+	Format     string           `yaml:"format"`      // default compare --format
+	PickerRoot string           `yaml:"picker_root"` // directory the file picker opens in
+	ServeDir   string           `yaml:"serve_dir"`   // directory "uxbench serve" loads reports from
+	Decimals   int              `yaml:"decimals"`    // decimal places for fractional metrics
+	Weights    analysis.Weights `yaml:"weights"`     // composite score weights
+
+	Metrics []analysis.CustomMetric `yaml:"metrics"` // derived metrics added to every output
+
+	Redact     []string `yaml:"redact"`      // metadata fields anonymize strips (default: all)
+	RedactURLs string   `yaml:"redact_urls"` // hash or drop
+
+	path string
+}
+
+// Path is the file the config was read from, or "" if none was found.
+func (c *Config) Path() string { return c.path }
+
+// UserPath is $XDG_CONFIG_HOME/uxbench/config.yaml (or the platform equivalent).
+func UserPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "uxbench", "config.yaml"), nil
+}
+
+// Load reads ./.uxbench.yaml, falling back to the user config file. A missing
+// file is not an error; a malformed one is.
+func Load() (*Config, error) {
+	candidates := []string{LocalFile}
+	if p, err := UserPath(); err == nil {
+		candidates = append(candidates, p)
+	}
+
+	for _, p := range candidates {
+		data, err := os.ReadFile(p)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config %s: %w", p, err)
+		}
+		cfg := &Config{Weights: analysis.DefaultWeights}
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config %s: %w", p, err)
+		}
+		cfg.path = p
+		return cfg, nil
+	}
+	return &Config{Weights: analysis.DefaultWeights}, nil
+}
+
+// DefaultFile is the commented template written by `uxbench config init`.
+const DefaultFile = `# uxbench configuration. Command-line flags override everything here.
+# Looked up as ./.uxbench.yaml first, then $XDG_CONFIG_HOME/uxbench/config.yaml.
+
+# Default output format for "uxbench compare": tui, markdown, csv, json, html, ascii or xlsx.
+format: tui
+
+# Directory the interactive file picker opens in. Empty = last used directory.
+picker_root: ""
+
+# Directory "uxbench serve" loads reports from. Empty = the working directory.
+serve_dir: ""
+
+# Decimal places for fractional metrics (Fitts ID, distances, composite score).
+decimals: 2
+
+# Composite score weights, matching the recorder's defaults.
+weights:
+  switches: 1.5   # each input mode switch
+  fitts: 1.0      # each bit of cumulative Fitts ID
+  scroll_px: 0.005 # each pixel scrolled (200px = 1 point)
+
+# Metadata fields stripped by "uxbench anonymize" and "compare --anonymize":
+# operator, persona, agent_model and urls. Empty = all of them. URLs are
+# replaced by a stable hash (redact_urls: hash) or removed (drop).
+redact: []
+redact_urls: hash
+
+# Custom metrics, computed from existing metric keys (see --metrics for the list)
+# and shown in every output under a "Custom" heading. format is float, integer,
+# percent or ms.
+# metrics:
+#   - label: Clicks per Minute
+#     expr: total_clicks / (time_on_task_ms / 60000)
+#     higher_is_better: false
+`
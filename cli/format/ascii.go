@@ -0,0 +1,84 @@
+package format
+
+import (
+	"strings"
+	"uxbench/schema"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// GenerateASCIITable creates a plain-ASCII bordered table (+---+ borders, no ANSI
+// color) for log viewers that mangle unicode. Winners are marked with "*", ties with "=".
+func GenerateASCIITable(reports []*schema.BenchmarkReport) string {
+	return GenerateASCIITableWithOptions(reports, Options{})
+}
+
+// GenerateASCIITableWithOptions is GenerateASCIITable limited to opts.Metrics when set.
+func GenerateASCIITableWithOptions(reports []*schema.BenchmarkReport, opts Options) string {
+	grid := BuildComparisonGrid(reports, opts)
+
+	// nil marks a separator line; category rows have only their first cell set
+	header := append([]string{"Metric"}, grid.Products...)
+	task := append([]string{"Task"}, grid.Tasks...)
+	rows := [][]string{nil, header, nil, task, nil}
+
+	for _, group := range grid.Groups {
+		if group.Category != "" {
+			rows = append(rows, []string{group.Category})
+		}
+		for _, gr := range group.Rows {
+			row := []string{gr.Metric.Label}
+			for i, cell := range gr.Cells {
+				switch gr.Marks[i] {
+				case MarkWinner:
+					cell += " *"
+				case MarkTie:
+					cell += " ="
+				default:
+					cell += "  " // keep values aligned with marked ones
+				}
+				row = append(row, cell)
+			}
+			rows = append(rows, row)
+		}
+	}
+	rows = append(rows, nil)
+
+	// Column widths from the widest cell (display width, so wide runes count double)
+	widths := make([]int, len(grid.Products)+1)
+	for _, row := range rows {
+		for i, c := range row {
+			if w := runewidth.StringWidth(c); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	var sb strings.Builder
+	for _, row := range rows {
+		if row == nil {
+			sb.WriteString("+")
+			for _, w := range widths {
+				sb.WriteString(strings.Repeat("-", w+2) + "+")
+			}
+			sb.WriteString("\n")
+			continue
+		}
+		sb.WriteString("|")
+		for i, w := range widths {
+			c := ""
+			if i < len(row) {
+				c = row[i]
+			}
+			if i == 0 {
+				sb.WriteString(" " + runewidth.FillRight(c, w) + " |")
+			} else {
+				sb.WriteString(" " + runewidth.FillLeft(c, w) + " |")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("* best value   = tied for best\n")
+
+	return sb.String()
+}
@@ -0,0 +1,39 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"uxbench/schema"
+)
+
+// ClickDetailsSection lists the report's ceremonial and wasted clicks with the
+// element clicked and why it was classified that way: the clicks a redesign
+// could remove.
+func ClickDetailsSection(r *schema.BenchmarkReport) string {
+	var sb strings.Builder
+	c := r.Metrics.ClickCount
+	writeClickDetails(&sb, "Ceremonial Clicks", c.Ceremonial, c.CeremonialDetails)
+	writeClickDetails(&sb, "Wasted Clicks", c.Wasted, c.WastedDetails)
+	return sb.String()
+}
+
+func writeClickDetails(sb *strings.Builder, title string, count int, details []schema.ClickContextDetail) {
+	sb.WriteString(fmt.Sprintf("%s (%d)\n", title, count))
+	if len(details) == 0 {
+		if count == 0 {
+			sb.WriteString("  (none)\n")
+		} else {
+			sb.WriteString("  (no details recorded)\n")
+		}
+		return
+	}
+	shown, more := Top(details)
+	width := len("Element")
+	for _, d := range shown {
+		width = max(width, len(d.Element))
+	}
+	for _, d := range shown {
+		sb.WriteString(fmt.Sprintf("  %-*s  %s\n", width, d.Element, d.Reason))
+	}
+	sb.WriteString(MoreLine(more))
+}
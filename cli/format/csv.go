@@ -1,6 +1,7 @@
 package format
 
 import (
+	"encoding/csv"
 	"fmt"
 	"strings"
 	"uxbench/schema"
@@ -8,30 +9,144 @@ import (
 
 // GenerateCSV creates a CSV formatted string for the comparison results.
 func GenerateCSV(reports []*schema.BenchmarkReport) string {
+	return GenerateCSVWithOptions(reports, Options{})
+}
+
+// GenerateCSVWithOptions is GenerateCSV with layout control. Cells are quoted
+// as needed, so product names and tasks may contain the delimiter, quotes or
+// line breaks.
+func GenerateCSVWithOptions(reports []*schema.BenchmarkReport, opts Options) string {
+	// CSV includes detail-only metrics
+	opts.Detail = true
+	grid := BuildComparisonGrid(reports, opts)
+
 	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	w.Comma = opts.csvDelimiter()
+	if opts.Transpose {
+		writeCSVTransposed(w, grid, opts)
+	} else {
+		writeCSVRows(w, grid, opts)
+	}
+	w.Flush()
+	return sb.String()
+}
 
-	// Header Row
-	sb.WriteString("Metric")
-	for _, r := range reports {
-		sb.WriteString(fmt.Sprintf(",%s", r.Metadata.Product))
+// csvDelimiter is opts.CSVDelimiter, or a comma when unset.
+func (o Options) csvDelimiter() rune {
+	if o.CSVDelimiter == 0 {
+		return ','
+	}
+	return o.CSVDelimiter
+}
+
+// csvNumber rewrites a FormatPlain value with opts.CSVDecimal as the decimal separator.
+func (o Options) csvNumber(s string) string {
+	if o.CSVDecimal == 0 || o.CSVDecimal == '.' {
+		return s
 	}
-	sb.WriteString("\n")
+	return strings.Replace(s, ".", string(o.CSVDecimal), 1)
+}
+
+// formulaPrefixes are the leading characters that make a spreadsheet treat a
+// cell as a formula (or, for tab and carriage return, hide one).
+const formulaPrefixes = "=+-@\t\r"
+
+// csvText escapes text cells as opts.CSVEscapeFormulas asks.
+func (o Options) csvText(cells ...string) []string {
+	if !o.CSVEscapeFormulas {
+		return cells
+	}
+	out := make([]string, len(cells))
+	for i, c := range cells {
+		out[i] = EscapeFormula(c)
+	}
+	return out
+}
+
+// EscapeFormula prefixes s with an apostrophe when it starts like a
+// spreadsheet formula, so "=HYPERLINK(...)" is shown rather than evaluated.
+// ParseCSV strips the apostrophe again.
+func EscapeFormula(s string) string {
+	if s != "" && strings.ContainsRune(formulaPrefixes, rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func writeCSVRows(w *csv.Writer, grid Grid, opts Options) {
+	// Header Row
+	w.Write(append([]string{"Metric"}, opts.csvText(grid.Products...)...))
 
 	// Task Row
-	sb.WriteString("Task")
-	for _, r := range reports {
-		sb.WriteString(fmt.Sprintf(",%s", r.Metadata.Task))
+	w.Write(append([]string{"Task"}, opts.csvText(grid.Tasks...)...))
+
+	// Source schema version of each product
+	w.Write(append([]string{"Schema Version"}, opts.csvText(grid.SchemaVersions...)...))
+
+	// Recording metadata, before the metric rows
+	for _, md := range grid.Metadata {
+		w.Write(append([]string{md.Label}, opts.csvText(md.Values...)...))
 	}
-	sb.WriteString("\n")
 
-	// All metrics from shared registry (CSV includes detail-only metrics)
-	for _, def := range MetricRegistry {
-		sb.WriteString(def.Label)
-		for _, r := range reports {
-			sb.WriteString(fmt.Sprintf(",%.2f", def.Extractor(r.Metrics)))
+	// Metrics identified by Key, each category introduced by a separator row carrying only its name
+	for _, group := range grid.Groups {
+		if group.Category != "" {
+			w.Write(append([]string{group.Category}, make([]string, len(grid.Products))...))
+		}
+		for _, row := range group.Rows {
+			rec := []string{row.Metric.Key}
+			for _, v := range row.Plain {
+				rec = append(rec, opts.csvNumber(v))
+			}
+			w.Write(rec)
 		}
-		sb.WriteString("\n")
 	}
 
-	return sb.String()
+	// Standings footer, as two machine-readable rows
+	if opts.Footer {
+		standings := grid.Standings()
+		won, score := []string{"Metrics Won"}, []string{"Average Score"}
+		for _, s := range standings {
+			won = append(won, fmt.Sprint(s.Wins))
+			score = append(score, s.ScorePlain())
+		}
+		w.Write(won)
+		w.Write(score)
+	}
+}
+
+// writeCSVTransposed writes one row per product and one column per metric,
+// plus the standings columns when opts.Footer is set.
+func writeCSVTransposed(w *csv.Writer, grid Grid, opts Options) {
+	rows := grid.Rows()
+
+	// Header Row
+	header := []string{"Product", "Task", "Schema Version"}
+	for _, md := range grid.Metadata {
+		header = append(header, md.Label)
+	}
+	for _, row := range rows {
+		header = append(header, row.Metric.Key)
+	}
+	var standings []Standing
+	if opts.Footer {
+		standings = grid.Standings()
+		header = append(header, "Metrics Won", "Average Score")
+	}
+	w.Write(header)
+
+	for p, product := range grid.Products {
+		rec := opts.csvText(product, grid.Tasks[p], grid.SchemaVersions[p])
+		for _, md := range grid.Metadata {
+			rec = append(rec, opts.csvText(md.Values[p])...)
+		}
+		for _, row := range rows {
+			rec = append(rec, opts.csvNumber(row.Plain[p]))
+		}
+		if standings != nil {
+			rec = append(rec, fmt.Sprint(standings[p].Wins), standings[p].ScorePlain())
+		}
+		w.Write(rec)
+	}
 }
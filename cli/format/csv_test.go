@@ -0,0 +1,125 @@
+package format
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"uxbench/schema"
+)
+
+func TestEscapeFormula(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"=HYPERLINK(\"http://x\")", "'=HYPERLINK(\"http://x\")"},
+		{"+1 555", "'+1 555"},
+		{"-Beta", "'-Beta"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\tTabbed", "'\tTabbed"},
+		{"\rReturn", "'\rReturn"},
+		{"Acme", "Acme"},
+		{"a=b", "a=b"},
+		{"'quoted", "'quoted"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got := EscapeFormula(tt.in)
+		if got != tt.want {
+			t.Errorf("EscapeFormula(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		if back := unescapeFormula(got); back != tt.in {
+			t.Errorf("unescapeFormula(%q) = %q, want %q", got, back, tt.in)
+		}
+	}
+}
+
+// hostileReports returns reports whose products and tasks start like
+// formulas or contain quotes, delimiters and line breaks.
+func hostileReports() []*schema.BenchmarkReport {
+	names := []struct{ product, task string }{
+		{"=HYPERLINK(\"http://evil\",\"x\")", "+cmd|' /C calc'!A0"},
+		{"-Minus", "@SUM(1+1)"},
+		{"\tTab", "\rReturn"},
+		{`Acme "Pro", v2`, "Line one\nline two"},
+		{"Semi;colon", "Plain task"},
+	}
+	reports := make([]*schema.BenchmarkReport, len(names))
+	for i, n := range names {
+		r := &schema.BenchmarkReport{SchemaVersion: "1.0"}
+		r.Metadata.Product = n.product
+		r.Metadata.Task = n.task
+		r.Metrics.ClickCount.Total = 10 + i
+		r.Metrics.CompositeScore = 50
+		reports[i] = r
+	}
+	return reports
+}
+
+func TestCSVFormulaEscaping(t *testing.T) {
+	reports := hostileReports()
+	for _, transpose := range []bool{false, true} {
+		for _, delim := range []rune{',', ';'} {
+			opts := Options{CSVEscapeFormulas: true, CSVDelimiter: delim, Transpose: transpose}
+			out := GenerateCSVWithOptions(reports, opts)
+
+			cr := csv.NewReader(strings.NewReader(out))
+			cr.Comma = delim
+			cr.FieldsPerRecord = -1
+			rows, err := cr.ReadAll()
+			if err != nil {
+				t.Fatalf("transpose=%v delim=%q: output does not parse: %v", transpose, delim, err)
+			}
+			var products, tasks []string
+			if transpose {
+				for _, row := range rows[1:] {
+					products, tasks = append(products, row[0]), append(tasks, row[1])
+				}
+			} else {
+				products, tasks = rows[0][1:], rows[1][1:]
+			}
+			for i, r := range reports {
+				if want := EscapeFormula(r.Metadata.Product); products[i] != want {
+					t.Errorf("transpose=%v delim=%q: product cell %q, want %q", transpose, delim, products[i], want)
+				}
+				if want := EscapeFormula(r.Metadata.Task); tasks[i] != want {
+					t.Errorf("transpose=%v delim=%q: task cell %q, want %q", transpose, delim, tasks[i], want)
+				}
+			}
+			for _, cell := range append(products, tasks...) {
+				if cell != "" && strings.ContainsRune(formulaPrefixes, rune(cell[0])) {
+					t.Errorf("transpose=%v delim=%q: cell %q starts like a formula", transpose, delim, cell)
+				}
+			}
+
+			back, err := ParseCSV(strings.NewReader(out))
+			if err != nil {
+				t.Fatalf("transpose=%v delim=%q: ParseCSV: %v", transpose, delim, err)
+			}
+			if len(back) != len(reports) {
+				t.Fatalf("transpose=%v delim=%q: ParseCSV returned %d reports, want %d", transpose, delim, len(back), len(reports))
+			}
+			for i, r := range reports {
+				if back[i].Metadata.Product != r.Metadata.Product || back[i].Metadata.Task != r.Metadata.Task {
+					t.Errorf("transpose=%v delim=%q: round trip gave %q / %q, want %q / %q", transpose, delim,
+						back[i].Metadata.Product, back[i].Metadata.Task, r.Metadata.Product, r.Metadata.Task)
+				}
+				if back[i].Metrics.ClickCount.Total != r.Metrics.ClickCount.Total {
+					t.Errorf("transpose=%v delim=%q: %s clicks = %d, want %d", transpose, delim,
+						r.Metadata.Product, back[i].Metrics.ClickCount.Total, r.Metrics.ClickCount.Total)
+				}
+			}
+		}
+	}
+}
+
+func TestCSVUnescapedByDefault(t *testing.T) {
+	reports := hostileReports()
+	rows, err := csv.NewReader(strings.NewReader(GenerateCSV(reports))).ReadAll()
+	if err != nil {
+		t.Fatalf("output does not parse: %v", err)
+	}
+	for i, r := range reports {
+		if rows[0][i+1] != r.Metadata.Product {
+			t.Errorf("product cell %q, want %q unchanged", rows[0][i+1], r.Metadata.Product)
+		}
+	}
+}
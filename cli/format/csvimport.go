@@ -0,0 +1,224 @@
+package format
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+	"uxbench/schema"
+)
+
+// metricSetters writes a metric value back into a report, keyed by MetricDef.Key.
+// Only metrics stored as a single number round-trip; idle_gaps and unique_urls
+// count lists that can't be reconstructed, and click_efficiency is computed
+// from the click counts, so they're absent here and ignored on import.
+// Human-signal rows are skipped too: a partial HumanSignals would read as real zeros.
+var metricSetters = map[string]func(*schema.BenchmarkReport, float64){
+	"composite_score":   func(r *schema.BenchmarkReport, v float64) { r.Metrics.CompositeScore = v },
+	"total_clicks":      func(r *schema.BenchmarkReport, v float64) { r.Metrics.ClickCount.Total = int(v) },
+	"navigation_count":  func(r *schema.BenchmarkReport, v float64) { r.Metadata.NavigationCount = int(v) },
+	"navigation_gap_ms": func(r *schema.BenchmarkReport, v float64) { r.Metadata.NavigationGapMS = int(v) },
+	"time_on_task_ms":   func(r *schema.BenchmarkReport, v float64) { r.Metrics.TimeOnTask.TotalMS = int(v) },
+	"active_time_ms":    func(r *schema.BenchmarkReport, v float64) { r.Metrics.TimeOnTask.ActiveMS = intPtr(v) },
+	"idle_time_ms":      func(r *schema.BenchmarkReport, v float64) { r.Metrics.TimeOnTask.IdleMS = intPtr(v) },
+	"fitts_avg_id":      func(r *schema.BenchmarkReport, v float64) { r.Metrics.Fitts.AverageID = v },
+	"fitts_max_id":      func(r *schema.BenchmarkReport, v float64) { r.Metrics.Fitts.MaxID = v },
+	"fitts_throughput_ms_bit": func(r *schema.BenchmarkReport, v float64) {
+		r.Metrics.Fitts.Throughput = &schema.FittsThroughput{BMsPerBit: v}
+	},
+	"context_switches":            func(r *schema.BenchmarkReport, v float64) { r.Metrics.ContextSwitches.Total = int(v) },
+	"shortcuts_used":              func(r *schema.BenchmarkReport, v float64) { r.Metrics.ShortcutCoverage.ShortcutsUsed = int(v) },
+	"scanning_dist_avg_px":        func(r *schema.BenchmarkReport, v float64) { r.Metrics.ScanningDistance.AveragePx = v },
+	"scroll_dist_px":              func(r *schema.BenchmarkReport, v float64) { r.Metrics.ScrollDistance.TotalPx = v },
+	"page_scroll_px":              func(r *schema.BenchmarkReport, v float64) { r.Metrics.ScrollDistance.PageScrollPx = &v },
+	"container_scroll_px":         func(r *schema.BenchmarkReport, v float64) { r.Metrics.ScrollDistance.ContainerScrollPx = &v },
+	"typing_ratio":                func(r *schema.BenchmarkReport, v float64) { r.Metrics.TypingRatio.Ratio = v },
+	"free_text_inputs":            func(r *schema.BenchmarkReport, v float64) { r.Metrics.TypingRatio.FreeTextInputs = int(v) },
+	"constrained_inputs":          func(r *schema.BenchmarkReport, v float64) { r.Metrics.TypingRatio.ConstrainedInputs = int(v) },
+	"productive_clicks":           func(r *schema.BenchmarkReport, v float64) { r.Metrics.ClickCount.Productive = int(v) },
+	"ceremonial_clicks":           func(r *schema.BenchmarkReport, v float64) { r.Metrics.ClickCount.Ceremonial = int(v) },
+	"wasted_clicks":               func(r *schema.BenchmarkReport, v float64) { r.Metrics.ClickCount.Wasted = int(v) },
+	"fitts_cumulative_id":         func(r *schema.BenchmarkReport, v float64) { r.Metrics.Fitts.CumulativeID = v },
+	"context_switch_ratio":        func(r *schema.BenchmarkReport, v float64) { r.Metrics.ContextSwitches.Ratio = v },
+	"scanning_dist_cumulative_px": func(r *schema.BenchmarkReport, v float64) { r.Metrics.ScanningDistance.CumulativePx = v },
+	"longest_idle_ms":             func(r *schema.BenchmarkReport, v float64) { r.Metrics.TimeOnTask.LongestIdleMS = intPtr(v) },
+	"path_efficiency":             func(r *schema.BenchmarkReport, v float64) { r.Metrics.Fitts.AveragePathEfficiency = &v },
+	"overshoots":                  func(r *schema.BenchmarkReport, v float64) { r.Metrics.Fitts.TotalOvershoots = intPtr(v) },
+	"longest_keyboard_streak": func(r *schema.BenchmarkReport, v float64) {
+		r.Metrics.ContextSwitches.LongestKeyboardStreak = intPtr(v)
+	},
+	"longest_mouse_streak": func(r *schema.BenchmarkReport, v float64) { r.Metrics.ContextSwitches.LongestMouseStreak = intPtr(v) },
+	"scroll_events":        func(r *schema.BenchmarkReport, v float64) { r.Metrics.ScrollDistance.ScrollEvents = intPtr(v) },
+}
+
+func intPtr(v float64) *int {
+	i := int(v)
+	return &i
+}
+
+// ParseCSV reconstructs minimal reports from a CSV written by GenerateCSV, in
+// either layout (metrics as rows, or --transpose). Only product, task, schema
+// version and the metric values mapped back via MetricRegistry keys survive the round trip:
+// idle gaps, hardest targets, free-text field names and other nested detail
+// are lost, percentages come back at the precision they were written with, and
+// "n/a" cells leave optional metrics unset. Category rows and unknown keys are skipped.
+// The delimiter is whatever follows the header's first cell, and numbers may
+// use a decimal comma (see Options.CSVDelimiter and Options.CSVDecimal).
+func ParseCSV(r io.Reader) ([]*schema.BenchmarkReport, error) {
+	br := bufio.NewReader(r)
+	cr := csv.NewReader(br)
+	cr.Comma = sniffCSVDelimiter(br)
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("empty CSV")
+	}
+
+	switch {
+	case len(rows[0]) >= 2 && rows[0][0] == "Product" && rows[0][1] == "Task":
+		return parseCSVTransposed(rows)
+	case rows[0][0] == "Metric":
+		return parseCSVRows(rows)
+	}
+	return nil, fmt.Errorf("not a uxbench comparison CSV (expected a \"Metric\" or \"Product,Task\" header)")
+}
+
+// sniffCSVDelimiter peeks at the header ("Metric" or "Product", then the
+// delimiter) and returns its delimiter, defaulting to a comma.
+func sniffCSVDelimiter(br *bufio.Reader) rune {
+	head, _ := br.Peek(len("Product") + 4)
+	for _, first := range []string{"Metric", "Product"} {
+		if rest, ok := strings.CutPrefix(string(head), first); ok && rest != "" {
+			if d, _ := utf8.DecodeRuneInString(rest); d != '\r' && d != '\n' && d != utf8.RuneError {
+				return d
+			}
+		}
+	}
+	return ','
+}
+
+// parseCSVRows reads the default layout: one column per product, one row per metric.
+func parseCSVRows(rows [][]string) ([]*schema.BenchmarkReport, error) {
+	var reports []*schema.BenchmarkReport
+	for _, product := range rows[0][1:] {
+		reports = append(reports, newImportedReport(unescapeFormula(product)))
+	}
+
+	for line, row := range rows[1:] {
+		if len(row) == 0 {
+			continue
+		}
+		if row[0] == "Task" {
+			for i, r := range reports {
+				r.Metadata.Task = textCell(row, i+1)
+			}
+			continue
+		}
+		if row[0] == "Schema Version" {
+			for i, r := range reports {
+				r.SchemaVersion = importedSchemaVersion(textCell(row, i+1))
+			}
+			continue
+		}
+		set, ok := metricSetters[row[0]]
+		if !ok {
+			continue
+		}
+		for i, r := range reports {
+			if err := setMetric(r, set, cell(row, i+1)); err != nil {
+				return nil, fmt.Errorf("line %d, %s: %w", line+2, row[0], err)
+			}
+		}
+	}
+	return reports, nil
+}
+
+// parseCSVTransposed reads the --transpose layout: one row per product.
+func parseCSVTransposed(rows [][]string) ([]*schema.BenchmarkReport, error) {
+	header := rows[0]
+	var reports []*schema.BenchmarkReport
+	for line, row := range rows[1:] {
+		if len(row) == 0 {
+			continue
+		}
+		r := newImportedReport(textCell(row, 0))
+		r.Metadata.Task = textCell(row, 1)
+		for col := 2; col < len(header); col++ {
+			if header[col] == "Schema Version" {
+				r.SchemaVersion = importedSchemaVersion(textCell(row, col))
+				continue
+			}
+			set, ok := metricSetters[header[col]]
+			if !ok {
+				continue
+			}
+			if err := setMetric(r, set, cell(row, col)); err != nil {
+				return nil, fmt.Errorf("line %d, %s: %w", line+2, header[col], err)
+			}
+		}
+		reports = append(reports, r)
+	}
+	return reports, nil
+}
+
+func newImportedReport(product string) *schema.BenchmarkReport {
+	return &schema.BenchmarkReport{
+		SchemaVersion: "1.0",
+		Source:        "custom",
+		Metadata:      schema.BenchmarkMetadata{Product: product, RecordingName: product + " (imported from CSV)"},
+	}
+}
+
+// importedSchemaVersion keeps the source schema version recorded in the CSV,
+// falling back to the current version for CSVs written before it was included.
+func importedSchemaVersion(v string) string {
+	if v == "" || v == "unknown" {
+		return "1.0"
+	}
+	return v
+}
+
+// setMetric parses a FormatPlain value ("12", "3.50", "45.0%", "n/a", or
+// "3,50" with a decimal comma) and applies it.
+func setMetric(r *schema.BenchmarkReport, set func(*schema.BenchmarkReport, float64), s string) error {
+	s = strings.Replace(strings.TrimSpace(s), ",", ".", 1)
+	if s == "" || s == "n/a" {
+		return nil
+	}
+	scale := 1.0
+	if strings.HasSuffix(s, "%") {
+		s = strings.TrimSuffix(s, "%")
+		scale = 0.01
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid value %q", s)
+	}
+	set(r, v*scale)
+	return nil
+}
+
+func cell(row []string, i int) string {
+	if i < len(row) {
+		return row[i]
+	}
+	return ""
+}
+
+// textCell is cell with the apostrophe EscapeFormula adds removed.
+func textCell(row []string, i int) string {
+	return unescapeFormula(cell(row, i))
+}
+
+func unescapeFormula(s string) string {
+	if len(s) >= 2 && s[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(s[1])) {
+		return s[1:]
+	}
+	return s
+}
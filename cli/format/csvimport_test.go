@@ -0,0 +1,84 @@
+package format
+
+import (
+	"strings"
+	"testing"
+
+	"uxbench/schema"
+)
+
+// notImported are the registry metrics ParseCSV can't restore (see metricSetters).
+var notImported = map[string]bool{
+	"unique_urls":           true, // counts Metadata.URLsVisited
+	"idle_gaps":             true, // counts TimeOnTask.IdleGaps
+	"decision_mean_ms":      true, // human signals are never partially rebuilt
+	"decision_p90_ms":       true,
+	"hover_hesitations":     true,
+	"near_miss_corrections": true,
+	"repeated_targeting":    true,
+}
+
+func TestMetricSettersCoverRegistry(t *testing.T) {
+	keys := map[string]bool{}
+	for _, def := range MetricRegistry {
+		keys[def.Key] = true
+		_, settable := metricSetters[def.Key]
+		switch {
+		case def.Key == "click_efficiency":
+			if settable {
+				t.Error("click_efficiency is derived from the click counts and shouldn't have a setter")
+			}
+		case notImported[def.Key] && settable:
+			t.Errorf("%s has a setter but is listed as not imported", def.Key)
+		case !notImported[def.Key] && !settable:
+			t.Errorf("%s has no setter in metricSetters, so it is dropped on CSV import", def.Key)
+		}
+	}
+	for key := range metricSetters {
+		if !keys[key] {
+			t.Errorf("metricSetters has %q, which is not a registry key", key)
+		}
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	modern := loadFixture(t, "new_report.json")
+	modern.Metadata.NavigationCount = 3
+	modern.Metadata.NavigationGapMS = 1250
+	reports := []*schema.BenchmarkReport{loadFixture(t, "old_report.json"), modern}
+
+	modes := []struct {
+		name string
+		opts Options
+	}{
+		{"rows", Options{}},
+		{"transpose", Options{Transpose: true}},
+		{"semicolon, decimal comma", Options{CSVDelimiter: ';', CSVDecimal: ','}},
+		{"transpose, semicolon, decimal comma", Options{Transpose: true, CSVDelimiter: ';', CSVDecimal: ','}},
+	}
+	for _, mode := range modes {
+		out := GenerateCSVWithOptions(reports, mode.opts)
+		back, err := ParseCSV(strings.NewReader(out))
+		if err != nil {
+			t.Fatalf("%s: ParseCSV: %v", mode.name, err)
+		}
+		if len(back) != len(reports) {
+			t.Fatalf("%s: got %d reports back, want %d", mode.name, len(back), len(reports))
+		}
+		for i, r := range reports {
+			got := back[i]
+			if got.Metadata.Product != r.Metadata.Product || got.Metadata.Task != r.Metadata.Task {
+				t.Errorf("%s: report %d is %q / %q, want %q / %q", mode.name, i,
+					got.Metadata.Product, got.Metadata.Task, r.Metadata.Product, r.Metadata.Task)
+			}
+			for _, def := range MetricRegistry {
+				if notImported[def.Key] {
+					continue
+				}
+				if want, have := def.FormatPlain(def.Value(r)), def.FormatPlain(def.Value(got)); have != want {
+					t.Errorf("%s: %s %s = %s after the round trip, want %s", mode.name, r.Metadata.Product, def.Key, have, want)
+				}
+			}
+		}
+	}
+}
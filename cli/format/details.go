@@ -0,0 +1,32 @@
+package format
+
+import "uxbench/schema"
+
+// DetailLine is a labeled descriptive fact about a report that doesn't fit the
+// numeric metric grid, e.g. which container was scrolled the most.
+type DetailLine struct {
+	Label string
+	Value string // "n/a" when the report doesn't carry it
+}
+
+// Details returns the report's descriptive detail lines, in display order.
+func Details(r *schema.BenchmarkReport) []DetailLine {
+	m := r.Metrics
+	norm := "n/a"
+	if r.HumanSignals != nil && r.HumanSignals.ThroughputIndex.ComparisonToNorm != "" {
+		norm = r.HumanSignals.ThroughputIndex.ComparisonToNorm
+	}
+	return []DetailLine{
+		{"Heaviest scroll container", optString(m.ScrollDistance.HeaviestContainer)},
+		{"Most switch-heavy moment", optString(m.ContextSwitches.MostSwitchHeavyMoment)},
+		{"Throughput vs. norm", norm},
+	}
+}
+
+// optString reads an optional string field, mapping nil or empty to "n/a".
+func optString(s *string) string {
+	if s == nil || *s == "" {
+		return "n/a"
+	}
+	return *s
+}
@@ -0,0 +1,179 @@
+package format
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"uxbench/schema"
+)
+
+// Change classifies a metric difference from the baseline's point of view.
+type Change string
+
+const (
+	ChangeImproved  Change = "improved"
+	ChangeRegressed Change = "regressed"
+	ChangeUnchanged Change = "unchanged"
+	ChangeMissing   Change = "n/a" // one or both reports lack the metric
+)
+
+// MetricDiff is the difference of a single registry metric between two reports.
+type MetricDiff struct {
+	Key            string   `json:"key"`
+	Metric         string   `json:"metric"`    // display label
+	Baseline       *float64 `json:"baseline"`  // nil when the report lacks the metric
+	Candidate      *float64 `json:"candidate"` // nil when the report lacks the metric
+	AbsDiff        *float64 `json:"abs_diff"`  // nil unless both values exist
+	PctDiff        *float64 `json:"pct_diff"`  // nil when the baseline is zero or either value is missing
+	Change         Change   `json:"change"`
+	HigherIsBetter bool     `json:"higher_is_better"`
+}
+
+// FieldDiff is a metadata field whose value differs between two reports.
+type FieldDiff struct {
+	Field     string `json:"field"`
+	Baseline  string `json:"baseline"`
+	Candidate string `json:"candidate"`
+}
+
+// ReportDiff is a focused comparison of a candidate report against a baseline.
+type ReportDiff struct {
+	Baseline  string       `json:"baseline"`
+	Candidate string       `json:"candidate"`
+	Metadata  []FieldDiff  `json:"metadata"`
+	Metrics   []MetricDiff `json:"metrics"`
+}
+
+// DiffReports compares every registry metric and the key metadata fields of b against a.
+func DiffReports(a, b *schema.BenchmarkReport) ReportDiff {
+	d := ReportDiff{
+		Baseline:  a.Metadata.Product,
+		Candidate: b.Metadata.Product,
+		Metadata:  []FieldDiff{},
+	}
+
+	fields := []struct {
+		name string
+		a, b string
+	}{
+		{"Product", a.Metadata.Product, b.Metadata.Product},
+		{"Task", a.Metadata.Task, b.Metadata.Task},
+		{"Browser", a.Metadata.Browser, b.Metadata.Browser},
+		{"Duration (ms)", fmt.Sprint(a.Metadata.DurationMS), fmt.Sprint(b.Metadata.DurationMS)},
+		{"Operator", a.Metadata.Operator, b.Metadata.Operator},
+		{"Persona", derefString(a.Metadata.Persona), derefString(b.Metadata.Persona)},
+		{"Agent Model", derefString(a.Metadata.AgentModel), derefString(b.Metadata.AgentModel)},
+		{"Source Version", a.Metadata.SourceVersion, b.Metadata.SourceVersion},
+		{"Schema Version", SchemaVersion(a), SchemaVersion(b)},
+	}
+	for _, f := range fields {
+		if f.a != f.b {
+			d.Metadata = append(d.Metadata, FieldDiff{Field: f.name, Baseline: f.a, Candidate: f.b})
+		}
+	}
+
+	for _, def := range MetricRegistry {
+		va, vb := def.Value(a), def.Value(b)
+		md := MetricDiff{
+			Key:            def.Key,
+			Metric:         def.Label,
+			Baseline:       OptionalValue(va),
+			Candidate:      OptionalValue(vb),
+			Change:         ChangeUnchanged,
+			HigherIsBetter: def.HigherIsBetter,
+		}
+		if IsMissing(va) || IsMissing(vb) {
+			md.Change = ChangeMissing
+			d.Metrics = append(d.Metrics, md)
+			continue
+		}
+		md.AbsDiff = OptionalValue(vb - va)
+		if va != 0 {
+			pct := (vb - va) / math.Abs(va) * 100 // a rise is positive even from a negative baseline
+			md.PctDiff = &pct
+		}
+		if !SameValue(va, vb) {
+			if (vb > va) == def.HigherIsBetter {
+				md.Change = ChangeImproved
+			} else {
+				md.Change = ChangeRegressed
+			}
+		}
+		d.Metrics = append(d.Metrics, md)
+	}
+	return d
+}
+
+// Regressions returns the diffs among keys that got worse by more than maxPct
+// percent of the baseline. A regression from a zero baseline has no percentage
+// and always counts.
+func (d ReportDiff) Regressions(keys []string, maxPct float64) []MetricDiff {
+	var out []MetricDiff
+	for _, m := range d.Metrics {
+		if m.Change != ChangeRegressed || !slices.Contains(keys, m.Key) {
+			continue
+		}
+		if m.PctDiff == nil || math.Abs(*m.PctDiff) > maxPct {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// GenerateDiffText renders a ReportDiff as an aligned plain-text table.
+func GenerateDiffText(d ReportDiff) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Diff: %s (baseline) → %s\n\n", d.Baseline, d.Candidate))
+
+	if len(d.Metadata) > 0 {
+		sb.WriteString("Metadata differences:\n")
+		for _, f := range d.Metadata {
+			sb.WriteString(fmt.Sprintf("  %-15s %s → %s\n", f.Field, f.Baseline, f.Candidate))
+		}
+		sb.WriteString("\n")
+	}
+
+	width := len("Metric")
+	for _, m := range d.Metrics {
+		if len(m.Metric) > width {
+			width = len(m.Metric)
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("%-*s  %12s  %12s  %12s  %9s\n", width, "Metric", "Baseline", "Candidate", "Diff", "%"))
+	for _, m := range d.Metrics {
+		pct := "n/a"
+		if m.PctDiff != nil {
+			pct = fmt.Sprintf("%+.1f%%", *m.PctDiff)
+		}
+		arrow := "="
+		switch m.Change {
+		case ChangeImproved:
+			arrow = "▲ better"
+		case ChangeRegressed:
+			arrow = "▼ worse"
+		case ChangeMissing:
+			arrow = "n/a"
+		}
+		sb.WriteString(fmt.Sprintf("%-*s  %12s  %12s  %12s  %9s  %s\n", width, m.Metric,
+			diffValue(m.Baseline, "%.*f"), diffValue(m.Candidate, "%.*f"), diffValue(m.AbsDiff, "%+.*f"), pct, arrow))
+	}
+	return sb.String()
+}
+
+// diffValue formats an optional diff value with Decimals places, or "n/a" when it is missing.
+func diffValue(v *float64, verb string) string {
+	if v == nil {
+		return "n/a"
+	}
+	return fmt.Sprintf(verb, Decimals, *v)
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
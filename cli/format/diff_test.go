@@ -0,0 +1,40 @@
+package format
+
+import "testing"
+
+func TestDiffReportsPctSign(t *testing.T) {
+	saved := MetricRegistry
+	t.Cleanup(func() { MetricRegistry = saved })
+	// A custom metric may be negative (e.g. a signed delta).
+	if err := RegisterMetric(scoreDef(true)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		baseline, candidate float64
+		pct                 float64
+		change              Change
+	}{
+		{10, 15, 50, ChangeImproved},
+		{10, 5, -50, ChangeRegressed},
+		{-10, -5, 50, ChangeImproved},
+		{-10, -15, -50, ChangeRegressed},
+		{-4, 2, 150, ChangeImproved},
+	}
+	for _, tt := range tests {
+		reports := scoreReports(tt.baseline, tt.candidate)
+		var got *MetricDiff
+		d := DiffReports(reports[0], reports[1])
+		for i := range d.Metrics {
+			if d.Metrics[i].Key == "score" {
+				got = &d.Metrics[i]
+			}
+		}
+		if got == nil {
+			t.Fatal("diff has no score metric")
+		}
+		if got.PctDiff == nil || *got.PctDiff != tt.pct || got.Change != tt.change {
+			t.Errorf("%v → %v: pct %v, %s; want %v, %s", tt.baseline, tt.candidate, optFloat(got.PctDiff), got.Change, tt.pct, tt.change)
+		}
+	}
+}
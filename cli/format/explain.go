@@ -0,0 +1,90 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Direction says which way a metric improves, e.g. "Lower is better".
+func (d MetricDef) Direction() string {
+	if d.HigherIsBetter {
+		return "Higher is better"
+	}
+	return "Lower is better"
+}
+
+// Arrow is a one-glyph direction marker for row labels: ↑ when higher is
+// better, ↓ when lower is better.
+func (d MetricDef) Arrow() string {
+	if d.HigherIsBetter {
+		return "↑"
+	}
+	return "↓"
+}
+
+// MarkdownLegend is the footnote under Markdown comparison tables.
+const MarkdownLegend = "_↑ higher is better, ↓ lower is better. **Bold** marks the best value; `=` marks a value tied for best._"
+
+// Legend explains the direction arrows and winner marks of the plain-text tables.
+const Legend = "↑ higher is better · ↓ lower is better · * best value · = tied for best"
+
+// Explain describes a metric for the glossary: label and key, the
+// description, then direction, category, unit and where it is shown.
+func Explain(def MetricDef) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s  (%s)\n", def.Label, def.Key))
+	if def.Description != "" {
+		sb.WriteString(indentWrap(def.Description, explainWidth))
+	}
+
+	facts := []string{def.Direction(), def.Category}
+	if def.Unit != "" {
+		facts = append(facts, "unit: "+def.Unit)
+	}
+	if def.DetailOnly {
+		facts = append(facts, "CSV/XLSX only")
+	}
+	sb.WriteString("  " + strings.Join(facts, " · ") + "\n")
+	return sb.String()
+}
+
+// explainWidth is the line length descriptions are wrapped to.
+const explainWidth = 78
+
+// indentWrap wraps s at word boundaries to width, indenting each line by two spaces.
+func indentWrap(s string, width int) string {
+	var sb strings.Builder
+	line := ""
+	for _, word := range strings.Fields(s) {
+		if line != "" && 2+len(line)+1+len(word) > width {
+			sb.WriteString("  " + line + "\n")
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		sb.WriteString("  " + line + "\n")
+	}
+	return sb.String()
+}
+
+// Glossary explains every registry metric, grouped by category.
+func Glossary() string {
+	var sb strings.Builder
+	for i, group := range GroupedMetrics(true) {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(group.Category + "\n\n")
+		for j, def := range group.Metrics {
+			if j > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(Explain(def))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,19 @@
+package format
+
+import "uxbench/schema"
+
+// Exporter is a text output format that can be written to a file.
+type Exporter struct {
+	Name     string
+	Ext      string
+	Generate func([]*schema.BenchmarkReport, Options) string
+}
+
+// Exporters lists the file formats offered when saving from the TUI.
+var Exporters = []Exporter{
+	{Name: "Markdown", Ext: "md", Generate: GenerateMarkdownTableWithOptions},
+	{Name: "CSV", Ext: "csv", Generate: GenerateCSVWithOptions},
+	{Name: "JSON", Ext: "json", Generate: GenerateJSONWithOptions},
+	{Name: "HTML", Ext: "html", Generate: GenerateHTMLWithOptions},
+	{Name: "ASCII", Ext: "txt", Generate: GenerateASCIITableWithOptions},
+}
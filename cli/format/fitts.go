@@ -0,0 +1,63 @@
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"uxbench/schema"
+)
+
+// HardestTargets returns the report's hardest-to-hit targets, highest Fitts ID
+// first. Reports without a top-3 list fall back to the single max-ID target.
+func HardestTargets(r *schema.BenchmarkReport) []schema.FittsTarget {
+	f := r.Metrics.Fitts
+	targets := make([]schema.FittsTarget, len(f.Top3Hardest))
+	copy(targets, f.Top3Hardest)
+	if len(targets) == 0 && f.MaxIDElement != "" {
+		targets = append(targets, schema.FittsTarget{
+			Element:    f.MaxIDElement,
+			ID:         f.MaxID,
+			DistancePx: f.MaxIDDistancePx,
+			TargetSize: f.MaxIDTargetSize,
+		})
+	}
+	sort.SliceStable(targets, func(i, j int) bool { return targets[i].ID > targets[j].ID })
+	return targets
+}
+
+// HardestTargetsSection lists the report's hardest targets, marking the worst with ▶.
+func HardestTargetsSection(r *schema.BenchmarkReport) string {
+	var sb strings.Builder
+	targets, more := Top(HardestTargets(r))
+
+	sb.WriteString("Hardest Targets\n")
+	if len(targets) == 0 {
+		sb.WriteString("  (none)\n")
+		return sb.String()
+	}
+
+	width := len("Element")
+	for _, t := range targets {
+		width = max(width, len(t.Element))
+	}
+	sb.WriteString(fmt.Sprintf("    %-*s  %6s  %9s  %s\n", width, "Element", "ID", "Distance", "Size"))
+	for i, t := range targets {
+		marker := " "
+		if i == 0 {
+			marker = "▶"
+		}
+		sb.WriteString(fmt.Sprintf("  %s %-*s  %6.2f  %7.0fpx  %s\n", marker, width, t.Element, t.ID, t.DistancePx, t.TargetSize))
+	}
+	sb.WriteString(MoreLine(more))
+	return sb.String()
+}
+
+// ThroughputSection shows the Fitts regression line (movement time = a + b·ID)
+// and its fit, or "n/a" for reports recorded without it.
+func ThroughputSection(r *schema.BenchmarkReport) string {
+	t := r.Metrics.Fitts.Throughput
+	if t == nil {
+		return "Fitts Throughput\n  n/a\n"
+	}
+	return fmt.Sprintf("Fitts Throughput\n  MT = %.0f ms + %.1f ms/bit × ID  (R² = %.2f)\n", t.AMS, t.BMsPerBit, t.RSquared)
+}
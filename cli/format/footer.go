@@ -0,0 +1,52 @@
+package format
+
+import "fmt"
+
+// Standing is one product's overall position across a grid's rows, for the
+// --footer row.
+type Standing struct {
+	Wins  int     // rows won outright (MarkWinner; ties count for nobody)
+	Score float64 // mean RadarScore over the rows the product has a value for, 0..1; Missing if none
+}
+
+// FooterLabel is the row (or column) heading for the standings footer.
+const FooterLabel = "Overall"
+
+// Standings sums up each product's wins and its average normalized score
+// (1 = best on every row), indexed like g.Products.
+func (g Grid) Standings() []Standing {
+	standings := make([]Standing, len(g.Products))
+	sums := make([]float64, len(g.Products))
+	counts := make([]int, len(g.Products))
+	for _, row := range g.Rows() {
+		for i, v := range row.Values {
+			if row.Marks[i] == MarkWinner {
+				standings[i].Wins++
+			}
+			if !IsMissing(v) {
+				sums[i] += RadarScore(v, row.Values, row.Metric.HigherIsBetter)
+				counts[i]++
+			}
+		}
+	}
+	for i := range standings {
+		standings[i].Score = Missing
+		if counts[i] > 0 {
+			standings[i].Score = sums[i] / float64(counts[i])
+		}
+	}
+	return standings
+}
+
+// String renders a standing for a table cell, e.g. "5 won · 68%".
+func (s Standing) String() string {
+	return fmt.Sprintf("%d won · %s", s.Wins, s.ScorePlain())
+}
+
+// ScorePlain renders the average score as a percentage, or "n/a".
+func (s Standing) ScorePlain() string {
+	if IsMissing(s.Score) {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.0f%%", s.Score*100)
+}
@@ -0,0 +1,26 @@
+package format
+
+import (
+	"sort"
+	"uxbench/schema"
+)
+
+// TopIdleGaps returns up to n idle gaps from the report, longest first.
+// n <= 0 returns all gaps.
+func TopIdleGaps(r *schema.BenchmarkReport, n int) []schema.IdleGap {
+	gaps := make([]schema.IdleGap, len(r.Metrics.TimeOnTask.IdleGaps))
+	copy(gaps, r.Metrics.TimeOnTask.IdleGaps)
+	sort.SliceStable(gaps, func(i, j int) bool { return gaps[i].GapMS > gaps[j].GapMS })
+	if n > 0 && len(gaps) > n {
+		gaps = gaps[:n]
+	}
+	return gaps
+}
+
+// WorstIdleCause returns the likely cause of the report's worst idle gap, or "" if unknown.
+func WorstIdleCause(r *schema.BenchmarkReport) string {
+	if r.HumanSignals == nil || r.HumanSignals.DecisionTime.WorstIdle == nil {
+		return ""
+	}
+	return r.HumanSignals.DecisionTime.WorstIdle.LikelyCause
+}
@@ -0,0 +1,141 @@
+package format
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"uxbench/schema"
+)
+
+// Grid is a comparison computed once from MetricRegistry and shared by every
+// table renderer (TUI, Markdown, CSV, ASCII, HTML, XLSX), so they all show the
+// same metrics with the same values and winners.
+type Grid struct {
+	Products       []string
+	Tasks          []string
+	SchemaVersions []string // each product's source schema version (see SchemaVersion)
+	Metadata       []MetadataRow
+	Groups         []GridGroup
+}
+
+// GridGroup is one category of rows. Category is empty for a --metrics selection.
+type GridGroup struct {
+	Category string
+	Rows     []GridRow
+}
+
+// GridRow is one metric across all products, indexed like Grid.Products.
+type GridRow struct {
+	Metric MetricDef
+	Values []float64 // raw values, Missing when a report lacks the metric
+	Cells  []string  // display values with units (FormatValue)
+	Plain  []string  // machine-readable values without units (FormatPlain)
+	Marks  []Mark
+	Trend  string // sparkline across products
+}
+
+// BuildComparisonGrid lays out reports by opts: opts.Metrics in order when set,
+// else the registry by category (DetailOnly metrics only when opts.Detail;
+// Human Signals only when a report has them), then reordered by opts.SortMetrics.
+func BuildComparisonGrid(reports []*schema.BenchmarkReport, opts Options) Grid {
+	g := Grid{Products: ProductLabels(reports)}
+	for _, r := range reports {
+		g.Tasks = append(g.Tasks, r.Metadata.Task)
+		g.SchemaVersions = append(g.SchemaVersions, SchemaVersion(r))
+	}
+	g.Metadata = MetadataRows(reports)
+
+	for _, group := range opts.Groups(opts.Detail) {
+		if !ShowsGroup(group, reports) {
+			continue
+		}
+		gg := GridGroup{Category: group.Category}
+		for _, def := range group.Metrics {
+			row := GridRow{
+				Metric: def,
+				Marks:  WinnerMarks(def, reports),
+				Trend:  MetricSparkline(def, reports),
+			}
+			for _, r := range reports {
+				v := def.Value(r)
+				row.Values = append(row.Values, v)
+				row.Cells = append(row.Cells, def.FormatValue(v))
+				row.Plain = append(row.Plain, def.FormatPlain(v))
+			}
+			gg.Rows = append(gg.Rows, row)
+		}
+		g.Groups = append(g.Groups, gg)
+	}
+
+	switch opts.SortMetrics {
+	case SortSpread:
+		rows := g.Rows()
+		sort.SliceStable(rows, func(i, j int) bool { return Spread(rows[i].Values) > Spread(rows[j].Values) })
+		g.Groups = []GridGroup{{Rows: rows}}
+	case SortAlpha:
+		rows := g.Rows()
+		sort.SliceStable(rows, func(i, j int) bool {
+			return strings.ToLower(rows[i].Metric.Label) < strings.ToLower(rows[j].Metric.Label)
+		})
+		g.Groups = []GridGroup{{Rows: rows}}
+	}
+	return g
+}
+
+// Spread is how much values differ, as their range relative to the largest
+// magnitude (0 = identical, 1 = one is zero, up to 2 across zero). Missing
+// values are ignored; fewer than two values have no spread.
+func Spread(values []float64) float64 {
+	min, max := Missing, Missing
+	n := 0
+	for _, v := range values {
+		if IsMissing(v) {
+			continue
+		}
+		n++
+		if IsMissing(min) || v < min {
+			min = v
+		}
+		if IsMissing(max) || v > max {
+			max = v
+		}
+	}
+	scale := math.Max(math.Abs(min), math.Abs(max))
+	if n < 2 || scale == 0 {
+		return 0
+	}
+	return (max - min) / scale
+}
+
+// Columns returns the grid cut to products [from, to). Marks and trends keep
+// their values from the whole grid, so winners stay global when a table is
+// split (see Options.SplitEvery).
+func (g Grid) Columns(from, to int) Grid {
+	cut := Grid{
+		Products:       g.Products[from:to],
+		Tasks:          g.Tasks[from:to],
+		SchemaVersions: g.SchemaVersions[from:to],
+	}
+	for _, md := range g.Metadata {
+		cut.Metadata = append(cut.Metadata, MetadataRow{Label: md.Label, Values: md.Values[from:to]})
+	}
+	for _, group := range g.Groups {
+		gg := GridGroup{Category: group.Category}
+		for _, row := range group.Rows {
+			row.Values, row.Cells, row.Plain, row.Marks = row.Values[from:to], row.Cells[from:to], row.Plain[from:to], row.Marks[from:to]
+			row.Trend = string([]rune(row.Trend)[from:to])
+			gg.Rows = append(gg.Rows, row)
+		}
+		cut.Groups = append(cut.Groups, gg)
+	}
+	return cut
+}
+
+// Rows is every row in display order, ignoring categories (for transposed layouts).
+func (g Grid) Rows() []GridRow {
+	var rows []GridRow
+	for _, group := range g.Groups {
+		rows = append(rows, group.Rows...)
+	}
+	return rows
+}
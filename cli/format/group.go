@@ -0,0 +1,118 @@
+package format
+
+import (
+	"fmt"
+	"uxbench/schema"
+)
+
+// Column labelling for Options.GroupBy.
+const (
+	GroupByProduct    = "product"
+	GroupByPersona    = "persona"
+	GroupByAgentModel = "agent_model"
+)
+
+// GroupByModes lists the valid Options.GroupBy values.
+var GroupByModes = []string{GroupByProduct, GroupByPersona, GroupByAgentModel}
+
+// groupLabel is the column label for r under by: the persona or agent model,
+// or the product when that field isn't set.
+func groupLabel(r *schema.BenchmarkReport, by string) (string, bool) {
+	var field *string
+	switch by {
+	case GroupByPersona:
+		field = r.Metadata.Persona
+	case GroupByAgentModel:
+		field = r.Metadata.AgentModel
+	}
+	if field == nil || *field == "" {
+		return r.Metadata.Product, false
+	}
+	return *field, true
+}
+
+// GroupReports relabels reports for comparing across personas or agent
+// models: each copy's Product becomes its persona or agent model, so every
+// output uses it as the column header, and reports of the same group are
+// moved next to each other (groups in first-seen order). A label shared by
+// different products gets the product added, e.g. "gpt-4o (Notion)". Reports
+// without the field keep their product name. GroupByProduct (or "") returns
+// reports unchanged.
+func GroupReports(reports []*schema.BenchmarkReport, by string) []*schema.BenchmarkReport {
+	if by == "" || by == GroupByProduct {
+		return reports
+	}
+
+	labels := make([]string, len(reports))
+	products := map[string]map[string]bool{} // label -> products carrying it
+	var order []string
+	for i, r := range reports {
+		label, _ := groupLabel(r, by)
+		labels[i] = label
+		if products[label] == nil {
+			products[label] = map[string]bool{}
+			order = append(order, label)
+		}
+		products[label][r.Metadata.Product] = true
+	}
+
+	var out []*schema.BenchmarkReport
+	for _, label := range order {
+		for i, r := range reports {
+			if labels[i] != label {
+				continue
+			}
+			c := *r
+			if _, set := groupLabel(r, by); set && len(products[label]) > 1 {
+				c.Metadata.Product = fmt.Sprintf("%s (%s)", label, r.Metadata.Product)
+			} else {
+				c.Metadata.Product = label
+			}
+			out = append(out, &c)
+		}
+	}
+	return out
+}
+
+// labelSuffixes are the metadata fields ProductLabels tries, in order, to tell
+// apart reports of the same product.
+var labelSuffixes = []func(schema.BenchmarkMetadata) string{
+	func(md schema.BenchmarkMetadata) string { return md.Operator },
+	func(md schema.BenchmarkMetadata) string { return derefString(md.Persona) },
+	func(md schema.BenchmarkMetadata) string { return derefString(md.AgentModel) },
+}
+
+// ProductLabels returns the column header for each report: its product, with
+// the operator, persona or agent model added when several reports share the
+// product, e.g. "Notion (Alice)" and "Notion (Bob)". For each shared product
+// the first of those fields that differs between its reports is used; when
+// none does, the product name is left alone.
+func ProductLabels(reports []*schema.BenchmarkReport) []string {
+	labels := make([]string, len(reports))
+	same := map[string][]int{} // product -> indexes of its reports
+	for i, r := range reports {
+		labels[i] = r.Metadata.Product
+		same[r.Metadata.Product] = append(same[r.Metadata.Product], i)
+	}
+	for _, idx := range same {
+		if len(idx) < 2 {
+			continue
+		}
+		for _, suffix := range labelSuffixes {
+			values := map[string]bool{}
+			for _, i := range idx {
+				values[suffix(reports[i].Metadata)] = true
+			}
+			if len(values) < 2 {
+				continue
+			}
+			for _, i := range idx {
+				if v := suffix(reports[i].Metadata); v != "" {
+					labels[i] = fmt.Sprintf("%s (%s)", labels[i], v)
+				}
+			}
+			break
+		}
+	}
+	return labels
+}
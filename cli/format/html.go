@@ -0,0 +1,75 @@
+package format
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+	"uxbench/schema"
+)
+
+const htmlStyle = `body{font-family:system-ui,sans-serif;margin:2em;color:#222}
+table{border-collapse:collapse}
+th,td{padding:4px 12px;border-bottom:1px solid #ddd;text-align:right}
+th:first-child,td:first-child{text-align:left}
+tr.category td{font-weight:bold;font-style:italic;color:#666;text-align:left}
+td.winner{color:#006100;background:#c6efce;font-weight:bold}
+td.tie{color:#9c5700;background:#ffeb9c;font-weight:bold}`
+
+// GenerateHTML creates a standalone HTML page with the comparison table.
+func GenerateHTML(reports []*schema.BenchmarkReport) string {
+	return GenerateHTMLWithOptions(reports, Options{})
+}
+
+// GenerateHTMLWithOptions is GenerateHTML limited to opts.Metrics when set.
+func GenerateHTMLWithOptions(reports []*schema.BenchmarkReport, opts Options) string {
+	var sb strings.Builder
+
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	sb.WriteString("<title>UX Bench Comparison Report</title>\n")
+	sb.WriteString("<style>\n" + htmlStyle + "\n</style>\n</head>\n<body>\n")
+	sb.WriteString("<h1>UX Bench Comparison Report</h1>\n")
+	sb.WriteString(fmt.Sprintf("<p>Generated on: %s</p>\n", time.Now().Format(time.RFC1123)))
+	sb.WriteString("<p>" + html.EscapeString(SchemaVersionLine(reports)) + "</p>\n")
+	if warning := MixedSchemaWarning(reports); warning != "" {
+		sb.WriteString("<p><strong>" + html.EscapeString(warning) + "</strong></p>\n")
+	}
+	for _, warning := range QualityWarnings(reports) {
+		sb.WriteString("<p>" + html.EscapeString(warning) + "</p>\n")
+	}
+
+	grid := BuildComparisonGrid(reports, opts)
+
+	sb.WriteString("<table>\n<tr><th>Metric</th>")
+	for _, p := range grid.Products {
+		sb.WriteString("<th>" + html.EscapeString(p) + "</th>")
+	}
+	sb.WriteString("</tr>\n<tr><td>Task</td>")
+	for _, t := range grid.Tasks {
+		sb.WriteString("<td>" + html.EscapeString(t) + "</td>")
+	}
+	sb.WriteString("</tr>\n")
+
+	for _, group := range grid.Groups {
+		if group.Category != "" {
+			sb.WriteString(fmt.Sprintf("<tr class=\"category\"><td colspan=\"%d\">%s</td></tr>\n", len(grid.Products)+1, html.EscapeString(group.Category)))
+		}
+		for _, row := range group.Rows {
+			sb.WriteString("<tr><td title=\"" + html.EscapeString(row.Metric.Description) + "\">" + html.EscapeString(row.Metric.Label) + "</td>")
+			for i, c := range row.Cells {
+				class := ""
+				switch row.Marks[i] {
+				case MarkWinner:
+					class = ` class="winner"`
+				case MarkTie:
+					class = ` class="tie"`
+				}
+				sb.WriteString(fmt.Sprintf("<td%s>%s</td>", class, html.EscapeString(c)))
+			}
+			sb.WriteString("</tr>\n")
+		}
+	}
+	sb.WriteString("</table>\n</body>\n</html>\n")
+
+	return sb.String()
+}
@@ -0,0 +1,67 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"uxbench/schema"
+)
+
+// maxFieldListWidth caps the rendered list of free-text field names.
+const maxFieldListWidth = 60
+
+// FreeTextFieldList joins the report's first TopN free-text field names, with
+// "+K more" for the rest, truncated with an ellipsis when the list gets long.
+// Returns "" if none were recorded.
+func FreeTextFieldList(r *schema.BenchmarkReport) string {
+	fields, more := Top(r.Metrics.TypingRatio.FreeTextFields)
+	list := strings.Join(fields, ", ")
+	if more > 0 {
+		list += fmt.Sprintf(", +%d more", more)
+	}
+	return truncate(list, maxFieldListWidth)
+}
+
+// truncate shortens s to at most n runes, ending in "…" when cut.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// HighestFreeTextBurden returns the report demanding the most free-text inputs,
+// breaking ties on the free/constrained ratio. Returns nil when there is nothing
+// to flag: fewer than two reports, or no free-text input at all.
+func HighestFreeTextBurden(reports []*schema.BenchmarkReport) *schema.BenchmarkReport {
+	if len(reports) < 2 {
+		return nil
+	}
+	var worst *schema.BenchmarkReport
+	for _, r := range reports {
+		t := r.Metrics.TypingRatio
+		if t.FreeTextInputs == 0 {
+			continue
+		}
+		if worst == nil || t.FreeTextInputs > worst.Metrics.TypingRatio.FreeTextInputs ||
+			(t.FreeTextInputs == worst.Metrics.TypingRatio.FreeTextInputs && t.Ratio > worst.Metrics.TypingRatio.Ratio) {
+			worst = r
+		}
+	}
+	return worst
+}
+
+// FreeTextBurdenNote describes the product with the highest free-text burden,
+// or "" when there is none.
+func FreeTextBurdenNote(reports []*schema.BenchmarkReport) string {
+	r := HighestFreeTextBurden(reports)
+	if r == nil {
+		return ""
+	}
+	t := r.Metrics.TypingRatio
+	note := fmt.Sprintf("Highest free-text burden: %s (%d free-text vs %d constrained inputs)", r.Metadata.Product, t.FreeTextInputs, t.ConstrainedInputs)
+	if fields := FreeTextFieldList(r); fields != "" {
+		note += ": " + fields
+	}
+	return note
+}
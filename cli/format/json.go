@@ -0,0 +1,48 @@
+package format
+
+import (
+	"encoding/json"
+	"time"
+	"uxbench/schema"
+)
+
+// jsonProduct is one product's entry in the JSON comparison output.
+type jsonProduct struct {
+	Product       string              `json:"product"`
+	Task          string              `json:"task"`
+	SchemaVersion string              `json:"schema_version"`
+	Metrics       map[string]*float64 `json:"metrics"` // by MetricDef.Key; nil (null) for missing metrics
+}
+
+// GenerateJSON creates a JSON document with every registry metric for each product.
+func GenerateJSON(reports []*schema.BenchmarkReport) string {
+	return GenerateJSONWithOptions(reports, Options{})
+}
+
+// GenerateJSONWithOptions is GenerateJSON limited to opts.Metrics when set.
+func GenerateJSONWithOptions(reports []*schema.BenchmarkReport, opts Options) string {
+	out := struct {
+		GeneratedAt    time.Time     `json:"generated_at"`
+		SchemaVersions []string      `json:"schema_versions"` // distinct source versions; more than one means mixed
+		Products       []jsonProduct `json:"products"`
+	}{GeneratedAt: time.Now(), SchemaVersions: SchemaVersions(reports)}
+
+	for _, r := range reports {
+		p := jsonProduct{Product: r.Metadata.Product, Task: r.Metadata.Task, SchemaVersion: SchemaVersion(r), Metrics: map[string]*float64{}}
+		for _, def := range opts.Defs(true) {
+			p.Metrics[def.Key] = OptionalValue(def.Round(def.Value(r)))
+		}
+		out.Products = append(out.Products, p)
+	}
+
+	data, _ := json.MarshalIndent(out, "", "  ") // plain structs and floats cannot fail
+	return string(data) + "\n"
+}
+
+// OptionalValue returns nil for Missing, so JSON output renders it as null.
+func OptionalValue(v float64) *float64 {
+	if IsMissing(v) {
+		return nil
+	}
+	return &v
+}
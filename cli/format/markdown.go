@@ -9,66 +9,252 @@ import (
 
 // GenerateMarkdownTable creates a Markdown formatted table string for the comparison results.
 func GenerateMarkdownTable(reports []*schema.BenchmarkReport) string {
+	return GenerateMarkdownTableWithOptions(reports, Options{})
+}
+
+// GenerateMarkdownTableWithOptions is GenerateMarkdownTable with layout control.
+func GenerateMarkdownTableWithOptions(reports []*schema.BenchmarkReport, opts Options) string {
 	var sb strings.Builder
 
 	sb.WriteString("# UX Bench Comparison Report\n")
 	sb.WriteString(fmt.Sprintf("Generated on: %s\n\n", time.Now().Format(time.RFC1123)))
+	sb.WriteString(SchemaVersionLine(reports) + "\n\n")
+	if warning := MixedSchemaWarning(reports); warning != "" {
+		sb.WriteString("> **" + warning + "**\n\n")
+	}
+	for _, warning := range QualityWarnings(reports) {
+		sb.WriteString("> " + warning + "\n\n")
+	}
+
+	if opts.Transpose {
+		writeMarkdownTransposed(&sb, reports, opts)
+	} else {
+		writeMarkdownSplit(&sb, reports, opts)
+	}
+	sb.WriteString("\n" + MarkdownLegend + "\n")
+
+	if note := FreeTextBurdenNote(reports); note != "" {
+		sb.WriteString("\n" + note + "\n")
+	}
+
+	writeMarkdownDetails(&sb, reports, opts.SplitEvery)
+	writeMarkdownIdleGaps(&sb, reports)
+
+	return sb.String()
+}
+
+// productChunks splits n products into [from, to) ranges of at most every
+// columns; every <= 0 keeps them in one.
+func productChunks(n, every int) [][2]int {
+	if every <= 0 || every >= n {
+		return [][2]int{{0, n}}
+	}
+	var chunks [][2]int
+	for from := 0; from < n; from += every {
+		chunks = append(chunks, [2]int{from, min(from+every, n)})
+	}
+	return chunks
+}
+
+// chunkTitle labels one of several split tables, e.g. "Products 1–4 of 10".
+func chunkTitle(chunk [2]int, n int) string {
+	return fmt.Sprintf("Products %d–%d of %d", chunk[0]+1, chunk[1], n)
+}
+
+// writeMarkdownSplit writes the default layout, as one table per
+// opts.SplitEvery products when set. The grid and standings are built once
+// over every product so the winners are the same as in a single table.
+func writeMarkdownSplit(sb *strings.Builder, reports []*schema.BenchmarkReport, opts Options) {
+	grid := BuildComparisonGrid(reports, opts)
+	var standings []Standing
+	if opts.Footer {
+		standings = grid.Standings()
+	}
+	chunks := productChunks(len(grid.Products), opts.SplitEvery)
+	for i, c := range chunks {
+		if len(chunks) > 1 {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString("### " + chunkTitle(c, len(grid.Products)) + "\n\n")
+		}
+		var cut []Standing
+		if standings != nil {
+			cut = standings[c[0]:c[1]]
+		}
+		writeMarkdownMetricRows(sb, grid.Columns(c[0], c[1]), cut)
+	}
+}
+
+// writeMarkdownMetricRows writes the default layout: one row per metric, one
+// column per product, and the footer row when standings are given.
+func writeMarkdownMetricRows(sb *strings.Builder, grid Grid, standings []Standing) {
 
 	// Header Row
 	sb.WriteString("| Metric |")
-	for _, r := range reports {
-		sb.WriteString(fmt.Sprintf(" %s |", r.Metadata.Product))
+	for _, p := range grid.Products {
+		sb.WriteString(fmt.Sprintf(" %s |", p))
 	}
-	sb.WriteString("\n")
+	sb.WriteString(" Trend |\n")
 
 	// Separator Row
 	sb.WriteString("|---|")
-	for range reports {
+	for range grid.Products {
 		sb.WriteString("---|")
 	}
-	sb.WriteString("\n")
-	
+	sb.WriteString("---|\n")
+
 	// Task Row
 	sb.WriteString("| **Task** |")
-	for _, r := range reports {
-		sb.WriteString(fmt.Sprintf(" %s |", r.Metadata.Task))
+	for _, t := range grid.Tasks {
+		sb.WriteString(fmt.Sprintf(" %s |", t))
+	}
+	sb.WriteString("  |\n")
+
+	// Recording metadata in italics, set apart from the metric rows
+	for _, md := range grid.Metadata {
+		sb.WriteString(fmt.Sprintf("| _%s_ |", md.Label))
+		for _, v := range md.Values {
+			sb.WriteString(fmt.Sprintf(" _%s_ |", v))
+		}
+		sb.WriteString("  |\n")
 	}
-	sb.WriteString("\n")
 
-	// Metric rows from shared registry (core metrics only)
-	for _, def := range MetricRegistry {
-		if def.DetailOnly {
-			continue
+	// Metric rows (core metrics only), grouped under bold category rows
+	for _, group := range grid.Groups {
+		if group.Category != "" {
+			sb.WriteString(fmt.Sprintf("| **%s** |%s\n", group.Category, strings.Repeat("  |", len(grid.Products)+1)))
 		}
-		sb.WriteString(fmt.Sprintf("| %s |", def.Label))
-
-		// Find best value
-		bestVal := -1.0
-		first := true
-		for _, r := range reports {
-			val := def.Extractor(r.Metrics)
-			if first {
-				bestVal = val
-				first = false
-			} else {
-				if def.HigherIsBetter {
-					if val > bestVal { bestVal = val }
-				} else {
-					if val < bestVal { bestVal = val }
-				}
+		for _, row := range group.Rows {
+			sb.WriteString(fmt.Sprintf("| %s %s |", row.Metric.Label, row.Metric.Arrow()))
+			for i, c := range row.Cells {
+				sb.WriteString(fmt.Sprintf(" %s |", markdownCell(c, row.Marks[i])))
 			}
+			sb.WriteString(fmt.Sprintf(" %s |\n", row.Trend))
 		}
+	}
 
-		for _, r := range reports {
-			val := def.Extractor(r.Metrics)
-			valStr := fmt.Sprintf("%.2f", val)
-			if val == bestVal {
-				valStr = "**" + valStr + "**" // Bold winner
-			}
-			sb.WriteString(fmt.Sprintf(" %s |", valStr))
+	if standings != nil {
+		sb.WriteString(fmt.Sprintf("| **%s** |", FooterLabel))
+		for _, s := range standings {
+			sb.WriteString(fmt.Sprintf(" %s |", s))
+		}
+		sb.WriteString("  |\n")
+	}
+}
+
+// markdownCell bolds the winning value; a value tied for best is bolded and marked "=".
+func markdownCell(val string, mark Mark) string {
+	switch mark {
+	case MarkWinner:
+		return "**" + val + "**"
+	case MarkTie:
+		return "**" + val + "** ="
+	}
+	return val
+}
+
+// writeMarkdownTransposed writes one row per product and one column per metric,
+// with a closing trend row. Winners are bolded down each column.
+func writeMarkdownTransposed(sb *strings.Builder, reports []*schema.BenchmarkReport, opts Options) {
+	grid := BuildComparisonGrid(reports, opts)
+	rows := grid.Rows()
+
+	// Header Row
+	sb.WriteString("| Product | Task |")
+	for _, md := range grid.Metadata {
+		sb.WriteString(fmt.Sprintf(" _%s_ |", md.Label))
+	}
+	for _, row := range rows {
+		sb.WriteString(fmt.Sprintf(" %s %s |", row.Metric.Label, row.Metric.Arrow()))
+	}
+	var standings []Standing
+	if opts.Footer {
+		standings = grid.Standings()
+		sb.WriteString(fmt.Sprintf(" **%s** |", FooterLabel))
+	}
+	sb.WriteString("\n|---|---|" + strings.Repeat("---|", len(grid.Metadata)+len(rows)+len(standings)) + "\n")
+
+	for p, product := range grid.Products {
+		sb.WriteString(fmt.Sprintf("| **%s** | %s |", product, grid.Tasks[p]))
+		for _, md := range grid.Metadata {
+			sb.WriteString(fmt.Sprintf(" _%s_ |", md.Values[p]))
+		}
+		for _, row := range rows {
+			sb.WriteString(fmt.Sprintf(" %s |", markdownCell(row.Cells[p], row.Marks[p])))
+		}
+		if standings != nil {
+			sb.WriteString(fmt.Sprintf(" %s |", standings[p]))
 		}
 		sb.WriteString("\n")
 	}
 
-	return sb.String()
+	sb.WriteString("| Trend |  |" + strings.Repeat("  |", len(grid.Metadata)))
+	for _, row := range rows {
+		sb.WriteString(fmt.Sprintf(" %s |", row.Trend))
+	}
+	if standings != nil {
+		sb.WriteString("  |")
+	}
+	sb.WriteString("\n")
+}
+
+// writeMarkdownDetails writes the descriptive detail lines side by side, split
+// like the metric table when splitEvery is set.
+func writeMarkdownDetails(sb *strings.Builder, reports []*schema.BenchmarkReport, splitEvery int) {
+	if len(reports) == 0 {
+		return
+	}
+	sb.WriteString("\n## Details\n")
+	chunks := productChunks(len(reports), splitEvery)
+	for _, c := range chunks {
+		if len(chunks) > 1 {
+			sb.WriteString("\n### " + chunkTitle(c, len(reports)) + "\n")
+		}
+		writeMarkdownDetailTable(sb, reports[c[0]:c[1]])
+	}
+}
+
+func writeMarkdownDetailTable(sb *strings.Builder, reports []*schema.BenchmarkReport) {
+	sb.WriteString("\n| Detail |")
+	for _, label := range ProductLabels(reports) {
+		sb.WriteString(fmt.Sprintf(" %s |", label))
+	}
+	sb.WriteString("\n|---|" + strings.Repeat("---|", len(reports)) + "\n")
+
+	perReport := make([][]DetailLine, len(reports))
+	for i, r := range reports {
+		perReport[i] = Details(r)
+	}
+	for row, d := range perReport[0] {
+		sb.WriteString(fmt.Sprintf("| %s |", d.Label))
+		for i := range reports {
+			sb.WriteString(fmt.Sprintf(" %s |", perReport[i][row].Value))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// writeMarkdownIdleGaps writes the idle gap breakdown per product.
+func writeMarkdownIdleGaps(sb *strings.Builder, reports []*schema.BenchmarkReport) {
+	sb.WriteString("\n## Idle Gaps\n")
+	labels := ProductLabels(reports)
+	for i, r := range reports {
+		sb.WriteString(fmt.Sprintf("\n### %s\n\n", labels[i]))
+		gaps, more := Top(TopIdleGaps(r, 0))
+		if len(gaps) == 0 {
+			sb.WriteString("No idle gaps recorded.\n")
+		} else {
+			sb.WriteString("| Gap (s) | After | Before |\n|---|---|---|\n")
+			for _, g := range gaps {
+				sb.WriteString(fmt.Sprintf("| %.1f | %s | %s |\n", g.GapMS/1000, g.AfterAction, g.BeforeAction))
+			}
+			if more > 0 {
+				sb.WriteString(fmt.Sprintf("\n+%d more\n", more))
+			}
+		}
+		if cause := WorstIdleCause(r); cause != "" {
+			sb.WriteString(fmt.Sprintf("\nWorst gap likely cause: **%s**\n", cause))
+		}
+	}
 }
@@ -0,0 +1,65 @@
+package format
+
+import (
+	"math"
+	"strings"
+	"time"
+	"uxbench/schema"
+
+	"github.com/dustin/go-humanize"
+)
+
+// MetadataRow is one recording fact per product (duration, browser, ...), shown
+// above the metric rows to help interpret them. Values are indexed like Grid.Products.
+type MetadataRow struct {
+	Label  string
+	Values []string // "n/a" when the report doesn't record it
+}
+
+// MetadataRows returns the recording metadata block for reports.
+func MetadataRows(reports []*schema.BenchmarkReport) []MetadataRow {
+	rows := []MetadataRow{{Label: "Duration"}, {Label: "Recorded"}, {Label: "Browser"}, {Label: "Operator"}, {Label: "Quality"}}
+	for _, r := range reports {
+		md := r.Metadata
+		rows[0].Values = append(rows[0].Values, HumanDuration(md.DurationMS))
+		rows[1].Values = append(rows[1].Values, Timestamp(md.Timestamp))
+		rows[2].Values = append(rows[2].Values, orNA(md.Browser))
+		rows[3].Values = append(rows[3].Values, orNA(md.Operator))
+		rows[4].Values = append(rows[4].Values, QualityLabel(r))
+	}
+	return rows
+}
+
+// durationMagnitudes keep go-humanize's wording but switch units late, so a
+// 90 second task reads "90 seconds" rather than "1 minute".
+var durationMagnitudes = []humanize.RelTimeMagnitude{
+	{D: time.Second, Format: "%d ms", DivBy: time.Millisecond},
+	{D: 2 * time.Minute, Format: "%d seconds", DivBy: time.Second},
+	{D: 2 * time.Hour, Format: "%d minutes", DivBy: time.Minute},
+	{D: math.MaxInt64, Format: "%d hours", DivBy: time.Hour},
+}
+
+// HumanDuration renders a recording duration for people, e.g. "45 seconds".
+func HumanDuration(ms int) string {
+	if ms <= 0 {
+		return "n/a"
+	}
+	var start time.Time
+	end := start.Add(time.Duration(ms) * time.Millisecond)
+	return strings.TrimSpace(humanize.CustomRelTime(start, end, "", "", durationMagnitudes))
+}
+
+// Timestamp renders when a recording was made, or "n/a" for a zero time.
+func Timestamp(t time.Time) string {
+	if t.IsZero() {
+		return "n/a"
+	}
+	return t.Format("2 Jan 2006 15:04 MST")
+}
+
+func orNA(s string) string {
+	if s == "" {
+		return "n/a"
+	}
+	return s
+}
@@ -1,13 +1,274 @@
 package format
 
-import "uxbench/schema"
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+	"uxbench/schema"
+)
 
 // MetricDef defines a single metric for use across all output formats (Markdown, CSV, TUI).
 type MetricDef struct {
-	Label          string
-	Extractor      func(schema.BenchmarkMetrics) float64
-	HigherIsBetter bool
-	DetailOnly     bool // true = included only in detailed formats (CSV); false = all formats
+	Label       string // display name; free to change
+	Key         string // stable snake_case identifier used by CSV/JSON, --metrics and --fail-if
+	Description string // what the metric measures and why its direction is better (explain, tooltips)
+	Extractor   func(schema.BenchmarkMetrics) float64
+	// ReportExtractor replaces Extractor for metrics kept outside Metrics
+	// (HumanSignals, navigation metadata). Read values through Value, which picks the right one.
+	ReportExtractor func(*schema.BenchmarkReport) float64
+	HigherIsBetter  bool
+	DetailOnly      bool   // true = included only in detailed formats (CSV); false = all formats
+	Category        string // one of Categories; outputs render metrics grouped under these headers
+	Unit            string // appended to displayed values; built-in labels name their unit instead ("Time on Task (ms)")
+	Format          ValueFormat
+}
+
+// ValueFormat is a rendering hint for metric values.
+type ValueFormat int
+
+const (
+	FormatFloat        ValueFormat = iota // Decimals places
+	FormatInteger                         // counts, no decimals
+	FormatPercent                         // 0..1 ratio shown as a percentage
+	FormatMilliseconds                    // whole milliseconds
+)
+
+// Missing is the value extractors return for metrics a report doesn't carry
+// (nil optional fields). Formatters render it as "n/a" and comparisons skip it.
+var Missing = math.NaN()
+
+// IsMissing reports whether v is the Missing sentinel.
+func IsMissing(v float64) bool {
+	return math.IsNaN(v)
+}
+
+// Value extracts the metric from a report, or Missing if the report lacks it.
+func (d MetricDef) Value(r *schema.BenchmarkReport) float64 {
+	if d.ReportExtractor != nil {
+		return d.ReportExtractor(r)
+	}
+	return d.Extractor(r.Metrics)
+}
+
+// IsNil reports whether the report lacks this metric (a nil optional field).
+func (d MetricDef) IsNil(r *schema.BenchmarkReport) bool {
+	return IsMissing(d.Value(r))
+}
+
+// humanSignal reads a HumanSignals field, mapping reports without human
+// signals (automated runs, older recordings) to Missing.
+func humanSignal(get func(*schema.HumanSignals) float64) func(*schema.BenchmarkReport) float64 {
+	return func(r *schema.BenchmarkReport) float64 {
+		if r.HumanSignals == nil {
+			return Missing
+		}
+		return get(r.HumanSignals)
+	}
+}
+
+// optInt and optFloat read optional schema fields, mapping nil to Missing.
+func optInt(p *int) float64 {
+	if p == nil {
+		return Missing
+	}
+	return float64(*p)
+}
+
+func optFloat(p *float64) float64 {
+	if p == nil {
+		return Missing
+	}
+	return *p
+}
+
+// Decimals is the number of decimal places used for FormatFloat values (--precision).
+// Percentages get one place fewer; integer and millisecond metrics get none.
+var Decimals = 2
+
+// Fixed renders a free-standing number (not tied to a metric) with Decimals places.
+func Fixed(v float64) string {
+	return fmt.Sprintf("%.*f", Decimals, v)
+}
+
+// places is the number of decimal places d is displayed with (as a percentage for FormatPercent).
+func (d MetricDef) places() int {
+	switch d.Format {
+	case FormatInteger, FormatMilliseconds:
+		return 0
+	case FormatPercent:
+		return max(Decimals-1, 0)
+	default:
+		return Decimals
+	}
+}
+
+// Round rounds v to the precision it is displayed with, for numeric outputs (JSON).
+func (d MetricDef) Round(v float64) float64 {
+	if IsMissing(v) {
+		return v
+	}
+	places := d.places()
+	if d.Format == FormatPercent {
+		places += 2 // stored as a 0..1 ratio
+	}
+	scale := math.Pow(10, float64(places))
+	return math.Round(v*scale) / scale
+}
+
+// FormatPlain renders v according to the metric's format hint, without the unit.
+// CSV uses it, so values stay human-formatted there ("34.0%", "n/a") and
+// ParseCSV undoes the formatting on import.
+func (d MetricDef) FormatPlain(v float64) string {
+	if IsMissing(v) {
+		return "n/a"
+	}
+	if d.Format == FormatPercent {
+		return fmt.Sprintf("%.*f%%", d.places(), v*100)
+	}
+	return fmt.Sprintf("%.*f", d.places(), v)
+}
+
+// FormatValue renders v for display, including the unit suffix.
+func (d MetricDef) FormatValue(v float64) string {
+	s := d.FormatPlain(v)
+	if d.Unit != "" && !IsMissing(v) {
+		s += " " + d.Unit
+	}
+	return s
+}
+
+// Metric categories, in display order.
+const (
+	CategoryEfficiency = "Efficiency"
+	CategoryErgonomics = "Ergonomics"
+	CategoryCognitive  = "Cognitive Load"
+	CategoryInput      = "Input"
+	CategoryHuman      = "Human Signals" // only human recordings carry these; see ShowsGroup
+	CategoryCustom     = "Custom"        // metrics added with RegisterMetric
+)
+
+var Categories = []string{CategoryEfficiency, CategoryErgonomics, CategoryCognitive, CategoryInput, CategoryHuman, CategoryCustom}
+
+// MetricGroup is a category header and the registry metrics that belong to it.
+type MetricGroup struct {
+	Category string
+	Metrics  []MetricDef
+}
+
+// GroupedMetrics returns the registry grouped by category in display order, keeping
+// registry order within each group. Detail-only metrics are included only if detail is true.
+func GroupedMetrics(detail bool) []MetricGroup {
+	var groups []MetricGroup
+	for _, cat := range Categories {
+		g := MetricGroup{Category: cat}
+		for _, def := range MetricRegistry {
+			if def.Category == cat && (detail || !def.DetailOnly) {
+				g.Metrics = append(g.Metrics, def)
+			}
+		}
+		if len(g.Metrics) > 0 {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// ShowsGroup reports whether a registry group is worth rendering for reports:
+// the Human Signals group is left out when none of them carries human signals,
+// rather than filling the table with n/a.
+func ShowsGroup(group MetricGroup, reports []*schema.BenchmarkReport) bool {
+	if group.Category != CategoryHuman {
+		return true
+	}
+	for _, r := range reports {
+		if r.HumanSignals != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// keyAliases maps unit-free metric names to the registry keys that carry a
+// unit suffix, so --metrics time_on_task works as well as time_on_task_ms.
+var keyAliases = map[string]string{
+	"time_on_task":             "time_on_task_ms",
+	"navigation_gap":           "navigation_gap_ms",
+	"active_time":              "active_time_ms",
+	"idle_time":                "idle_time_ms",
+	"longest_idle":             "longest_idle_ms",
+	"fitts_throughput":         "fitts_throughput_ms_bit",
+	"scanning_dist_avg":        "scanning_dist_avg_px",
+	"scanning_dist_cumulative": "scanning_dist_cumulative_px",
+	"scroll_dist":              "scroll_dist_px",
+	"page_scroll":              "page_scroll_px",
+	"container_scroll":         "container_scroll_px",
+	"decision_mean":            "decision_mean_ms",
+	"decision_p90":             "decision_p90_ms",
+}
+
+// CanonicalKey returns the registry key for name, resolving unit-free aliases.
+// Names that aren't aliases are returned unchanged.
+func CanonicalKey(name string) string {
+	if key, ok := keyAliases[name]; ok {
+		return key
+	}
+	return name
+}
+
+// IsSnakeKey reports whether key is lowercase snake_case: lowercase letters
+// and digits in words joined by single underscores, starting with a letter.
+func IsSnakeKey(key string) bool {
+	prev := '_'
+	for i, r := range key {
+		switch {
+		case r == '_':
+			if prev == '_' {
+				return false
+			}
+		case unicode.IsDigit(r):
+			if i == 0 {
+				return false
+			}
+		case !unicode.IsLetter(r) || unicode.IsUpper(r):
+			return false
+		}
+		prev = r
+	}
+	return key != "" && prev != '_'
+}
+
+// RegisterMetric appends a metric to MetricRegistry so every formatter and the
+// TUI pick it up. It must be called before any output is built. Label and Key
+// must be set and unused, and Key must be lowercase snake_case (see
+// IsSnakeKey); an empty Category defaults to CategoryCustom.
+func RegisterMetric(def MetricDef) error {
+	if def.Label == "" || def.Key == "" {
+		return fmt.Errorf("metric needs both a label and a key")
+	}
+	if !IsSnakeKey(def.Key) {
+		return fmt.Errorf("metric key %q must be lowercase snake_case, e.g. clicks_per_minute", def.Key)
+	}
+	if _, ok := keyAliases[def.Key]; ok {
+		return fmt.Errorf("metric key %q is reserved as another name for %q", def.Key, keyAliases[def.Key])
+	}
+	if def.Extractor == nil && def.ReportExtractor == nil {
+		return fmt.Errorf("metric %q has no extractor", def.Key)
+	}
+	for _, d := range MetricRegistry {
+		if d.Key == def.Key || strings.EqualFold(d.Label, def.Label) {
+			return fmt.Errorf("metric %q clashes with existing metric %q", def.Key, d.Key)
+		}
+	}
+	if def.Category == "" {
+		def.Category = CategoryCustom
+	}
+	if !slices.Contains(Categories, def.Category) {
+		return fmt.Errorf("metric %q has unknown category %q", def.Key, def.Category)
+	}
+	MetricRegistry = append(MetricRegistry, def)
+	return nil
 }
 
 // MetricRegistry is the single source of truth for which metrics appear in comparison outputs.
@@ -15,22 +276,67 @@ type MetricDef struct {
 // CSV includes all entries.
 var MetricRegistry = []MetricDef{
 	// --- Core metrics (all formats) ---
-	{Label: "Composite Score", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.CompositeScore }, HigherIsBetter: true},
-	{Label: "Total Clicks", Extractor: func(m schema.BenchmarkMetrics) float64 { return float64(m.ClickCount.Total) }},
-	{Label: "Time on Task (ms)", Extractor: func(m schema.BenchmarkMetrics) float64 { return float64(m.TimeOnTask.TotalMS) }},
-	{Label: "Fitts Avg ID", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.Fitts.AverageID }},
-	{Label: "Context Switches", Extractor: func(m schema.BenchmarkMetrics) float64 { return float64(m.ContextSwitches.Total) }},
-	{Label: "Shortcuts Used", Extractor: func(m schema.BenchmarkMetrics) float64 { return float64(m.ShortcutCoverage.ShortcutsUsed) }, HigherIsBetter: true},
-	{Label: "Scanning Dist (avg px)", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.ScanningDistance.AveragePx }},
-	{Label: "Scroll Dist (px)", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.ScrollDistance.TotalPx }},
-	{Label: "Typing Ratio", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.TypingRatio.Ratio }},
+	{Label: "Composite Score", Key: "composite_score", Description: "The recorder's single summary score, built from input mode switches, Fitts difficulty and scrolling (summary shows the breakdown). Use it for a quick ranking, then check the rows behind it.", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.CompositeScore }, HigherIsBetter: true, Category: CategoryEfficiency},
+	{Label: "Total Clicks", Key: "total_clicks", Description: "Every click made during the task. Each click is an action the user has to plan and aim, so fewer clicks for the same task means less effort.", Extractor: func(m schema.BenchmarkMetrics) float64 { return float64(m.ClickCount.Total) }, Category: CategoryEfficiency, Format: FormatInteger},
+	{Label: "Click Efficiency", Key: "click_efficiency", Description: "Share of clicks that were productive (productive / total). It separates a flow that needs many clicks from one that wastes them: higher means less ceremony and fewer dead ends.", Extractor: func(m schema.BenchmarkMetrics) float64 {
+		// No clicks, or a recording that didn't classify them, has no ratio to compare
+		c := m.ClickCount
+		if c.Total == 0 || c.Productive+c.Ceremonial+c.Wasted == 0 {
+			return Missing
+		}
+		return float64(c.Productive) / float64(c.Total)
+	}, HigherIsBetter: true, Category: CategoryEfficiency, Format: FormatPercent},
+	{Label: "Time on Task (ms)", Key: "time_on_task_ms", Description: "Wall-clock time from the first to the last action. Faster completion of the same task usually means a clearer, shorter flow.", Extractor: func(m schema.BenchmarkMetrics) float64 { return float64(m.TimeOnTask.TotalMS) }, Category: CategoryEfficiency, Format: FormatMilliseconds},
+	{Label: "Navigations", Key: "navigation_count", Description: "Page loads during the task. Each one interrupts the user and waits on the network, so fewer is better.", ReportExtractor: func(r *schema.BenchmarkReport) float64 { return float64(r.Metadata.NavigationCount) }, Category: CategoryEfficiency, Format: FormatInteger},
+	{Label: "Navigation Gap (ms)", Key: "navigation_gap_ms", Description: "Total time spent waiting between leaving one page and acting on the next. Less waiting keeps users in flow.", ReportExtractor: func(r *schema.BenchmarkReport) float64 { return float64(r.Metadata.NavigationGapMS) }, Category: CategoryEfficiency, Format: FormatMilliseconds},
+	{Label: "Unique URLs", Key: "unique_urls", Description: "Distinct pages visited, including the start URL. Needing more pages for the same task suggests a fragmented flow.", ReportExtractor: uniqueURLCount, Category: CategoryEfficiency, Format: FormatInteger},
+	{Label: "Active Time (ms)", Key: "active_time_ms", Description: "Time the user was actively clicking, typing or scrolling. Less active time means less hands-on work.", Extractor: func(m schema.BenchmarkMetrics) float64 { return optInt(m.TimeOnTask.ActiveMS) }, Category: CategoryEfficiency, Format: FormatMilliseconds},
+	{Label: "Idle Time (ms)", Key: "idle_time_ms", Description: "Time with no input, often spent reading, searching or deciding. Long idle time points at confusing screens.", Extractor: func(m schema.BenchmarkMetrics) float64 { return optInt(m.TimeOnTask.IdleMS) }, Category: CategoryCognitive, Format: FormatMilliseconds},
+	{Label: "Idle Gaps", Key: "idle_gaps", Description: "Pauses long enough to count as idle. Each gap is a moment the user likely had to stop and think.", Extractor: func(m schema.BenchmarkMetrics) float64 {
+		// null/absent (not captured) is missing; an empty list is a real 0
+		if m.TimeOnTask.IdleGaps == nil {
+			return Missing
+		}
+		return float64(len(m.TimeOnTask.IdleGaps))
+	}, Category: CategoryCognitive, Format: FormatInteger},
+	{Label: "Fitts Avg ID", Key: "fitts_avg_id", Description: "Average Fitts index of difficulty (bits) per pointer move: how hard targets are to hit given their size and distance. Smaller, farther targets score higher and take longer to reach.", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.Fitts.AverageID }, Category: CategoryErgonomics},
+	{Label: "Fitts Max ID", Key: "fitts_max_id", Description: "The hardest single pointer move (bits). A high value flags one tiny or distant target worth enlarging or moving.", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.Fitts.MaxID }, Category: CategoryErgonomics},
+	{Label: "Fitts Throughput (ms/bit)", Key: "fitts_throughput_ms_bit", Description: "Slope of the Fitts regression: extra milliseconds per bit of difficulty. A lower slope means pointing gets less costly as targets get harder.", Extractor: func(m schema.BenchmarkMetrics) float64 {
+		if m.Fitts.Throughput == nil {
+			return Missing
+		}
+		return m.Fitts.Throughput.BMsPerBit
+	}, Category: CategoryErgonomics},
+	{Label: "Context Switches", Key: "context_switches", Description: "Switches between keyboard and mouse. Each switch costs a hand movement and a moment of reorientation.", Extractor: func(m schema.BenchmarkMetrics) float64 { return float64(m.ContextSwitches.Total) }, Category: CategoryCognitive, Format: FormatInteger},
+	{Label: "Shortcuts Used", Key: "shortcuts_used", Description: "Keyboard shortcuts used during the task. More shortcuts means the interface supports faster, expert-friendly input.", Extractor: func(m schema.BenchmarkMetrics) float64 { return float64(m.ShortcutCoverage.ShortcutsUsed) }, HigherIsBetter: true, Category: CategoryInput, Format: FormatInteger},
+	{Label: "Scanning Dist (avg px)", Key: "scanning_dist_avg_px", Description: "Average distance (px) between consecutive points of interaction. Large jumps mean the eyes have to travel further to find the next control.", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.ScanningDistance.AveragePx }, Category: CategoryErgonomics},
+	{Label: "Scroll Dist (px)", Key: "scroll_dist_px", Description: "Total distance scrolled (px), page and containers combined. Scrolling hides content and costs time, so less is better.", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.ScrollDistance.TotalPx }, Category: CategoryErgonomics},
+	{Label: "Page Scroll (px)", Key: "page_scroll_px", Description: "Distance the page itself was scrolled (px). Less means the key content sits closer to the top.", Extractor: func(m schema.BenchmarkMetrics) float64 { return optFloat(m.ScrollDistance.PageScrollPx) }, Category: CategoryErgonomics},
+	{Label: "Container Scroll (px)", Key: "container_scroll_px", Description: "Distance scrolled inside inner panels and lists (px). Nested scrolling is easy to miss, so less is better.", Extractor: func(m schema.BenchmarkMetrics) float64 { return optFloat(m.ScrollDistance.ContainerScrollPx) }, Category: CategoryErgonomics},
+	{Label: "Typing Ratio", Key: "typing_ratio", Description: "Share of inputs that were free text rather than constrained choices (pickers, checkboxes). Free text is slower and more error-prone than picking.", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.TypingRatio.Ratio }, Category: CategoryInput, Format: FormatPercent},
+	{Label: "Free-Text Inputs", Key: "free_text_inputs", Description: "Inputs filled in by typing free text. Each one asks the user to recall and type an answer.", Extractor: func(m schema.BenchmarkMetrics) float64 { return float64(m.TypingRatio.FreeTextInputs) }, Category: CategoryInput, Format: FormatInteger},
+	{Label: "Constrained Inputs", Key: "constrained_inputs", Description: "Inputs answered by choosing (selects, pickers, checkboxes). Counted for context: a low typing ratio comes from these.", Extractor: func(m schema.BenchmarkMetrics) float64 { return float64(m.TypingRatio.ConstrainedInputs) }, Category: CategoryInput, Format: FormatInteger},
 
 	// --- Detail-only metrics (CSV) ---
-	{Label: "Productive Clicks", Extractor: func(m schema.BenchmarkMetrics) float64 { return float64(m.ClickCount.Productive) }, DetailOnly: true},
-	{Label: "Ceremonial Clicks", Extractor: func(m schema.BenchmarkMetrics) float64 { return float64(m.ClickCount.Ceremonial) }, DetailOnly: true},
-	{Label: "Wasted Clicks", Extractor: func(m schema.BenchmarkMetrics) float64 { return float64(m.ClickCount.Wasted) }, DetailOnly: true},
-	{Label: "Fitts Cumulative ID", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.Fitts.CumulativeID }, DetailOnly: true},
-	{Label: "Fitts Max ID", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.Fitts.MaxID }, DetailOnly: true},
-	{Label: "Context Switch Ratio", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.ContextSwitches.Ratio }, DetailOnly: true},
-	{Label: "Scanning Dist (cumulative px)", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.ScanningDistance.CumulativePx }, DetailOnly: true},
+	{Label: "Productive Clicks", Key: "productive_clicks", Description: "Clicks that moved the task forward. Fewer are better here too, since the same task done in fewer steps is more efficient.", Extractor: func(m schema.BenchmarkMetrics) float64 { return float64(m.ClickCount.Productive) }, DetailOnly: true, Category: CategoryEfficiency, Format: FormatInteger},
+	{Label: "Ceremonial Clicks", Key: "ceremonial_clicks", Description: "Clicks the UI demanded without progressing the task (confirmations, tab switches, expanding sections). Pure overhead.", Extractor: func(m schema.BenchmarkMetrics) float64 { return float64(m.ClickCount.Ceremonial) }, DetailOnly: true, Category: CategoryEfficiency, Format: FormatInteger},
+	{Label: "Wasted Clicks", Key: "wasted_clicks", Description: "Clicks that did nothing useful (misses, dead ends, undone actions). Each one is a small failure the user had to recover from.", Extractor: func(m schema.BenchmarkMetrics) float64 { return float64(m.ClickCount.Wasted) }, DetailOnly: true, Category: CategoryEfficiency, Format: FormatInteger},
+	{Label: "Fitts Cumulative ID", Key: "fitts_cumulative_id", Description: "Sum of the Fitts difficulty of every pointer move (bits). Captures both how many moves were needed and how hard they were.", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.Fitts.CumulativeID }, DetailOnly: true, Category: CategoryErgonomics},
+	{Label: "Context Switch Ratio", Key: "context_switch_ratio", Description: "Keyboard/mouse switches per action. A lower ratio means users could stay on one input device.", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.ContextSwitches.Ratio }, DetailOnly: true, Category: CategoryCognitive, Format: FormatPercent},
+	{Label: "Scanning Dist (cumulative px)", Key: "scanning_dist_cumulative_px", Description: "Total distance (px) between consecutive points of interaction. Less travel means related controls sit closer together.", Extractor: func(m schema.BenchmarkMetrics) float64 { return m.ScanningDistance.CumulativePx }, DetailOnly: true, Category: CategoryErgonomics},
+
+	// --- Optional metrics (nil when the recorder didn't capture them) ---
+	{Label: "Longest Idle (ms)", Key: "longest_idle_ms", Description: "The single longest pause. A long one usually marks the most confusing moment in the flow.", Extractor: func(m schema.BenchmarkMetrics) float64 { return optInt(m.TimeOnTask.LongestIdleMS) }, DetailOnly: true, Category: CategoryCognitive, Format: FormatMilliseconds},
+	{Label: "Path Efficiency", Key: "path_efficiency", Description: "How direct pointer paths were: straight-line distance over the distance actually moved. Closer to 100% means users knew where to go.", Extractor: func(m schema.BenchmarkMetrics) float64 { return optFloat(m.Fitts.AveragePathEfficiency) }, HigherIsBetter: true, DetailOnly: true, Category: CategoryErgonomics, Format: FormatPercent},
+	{Label: "Overshoots", Key: "overshoots", Description: "Pointer moves that went past the target and came back. They suggest small or poorly placed targets.", Extractor: func(m schema.BenchmarkMetrics) float64 { return optInt(m.Fitts.TotalOvershoots) }, DetailOnly: true, Category: CategoryErgonomics, Format: FormatInteger},
+	{Label: "Longest Keyboard Streak", Key: "longest_keyboard_streak", Description: "Most consecutive actions on the keyboard alone. Shown for context; long streaks point at keyboard-heavy stretches.", Extractor: func(m schema.BenchmarkMetrics) float64 { return optInt(m.ContextSwitches.LongestKeyboardStreak) }, DetailOnly: true, Category: CategoryCognitive, Format: FormatInteger},
+	{Label: "Longest Mouse Streak", Key: "longest_mouse_streak", Description: "Most consecutive actions with the mouse alone. Shown for context; long streaks point at pointer-heavy stretches.", Extractor: func(m schema.BenchmarkMetrics) float64 { return optInt(m.ContextSwitches.LongestMouseStreak) }, DetailOnly: true, Category: CategoryCognitive, Format: FormatInteger},
+	{Label: "Scroll Events", Key: "scroll_events", Description: "Separate scroll gestures. Fewer gestures means less hunting for content.", Extractor: func(m schema.BenchmarkMetrics) float64 { return optInt(m.ScrollDistance.ScrollEvents) }, DetailOnly: true, Category: CategoryErgonomics, Format: FormatInteger},
+
+	// --- Human signals (report-level; Missing for automated runs) ---
+	{Label: "Decision Time (mean ms)", Key: "decision_mean_ms", Description: "Average pause before each action, as a proxy for how long users had to think. Shorter means clearer choices.", ReportExtractor: humanSignal(func(h *schema.HumanSignals) float64 { return h.DecisionTime.MeanMS }), Category: CategoryHuman, Format: FormatMilliseconds},
+	{Label: "Decision Time (p90 ms)", Key: "decision_p90_ms", Description: "The decision time 90% of actions came in under. Catches the slow, hard decisions the mean hides.", ReportExtractor: humanSignal(func(h *schema.HumanSignals) float64 { return h.DecisionTime.P90MS }), Category: CategoryHuman, Format: FormatMilliseconds},
+	{Label: "Hover Hesitations", Key: "hover_hesitations", Description: "Times the pointer lingered over a control without clicking. A sign of uncertainty about what a control does.", ReportExtractor: humanSignal(func(h *schema.HumanSignals) float64 { return float64(h.Hesitation.HoverHesitations) }), Category: CategoryHuman, Format: FormatInteger},
+	{Label: "Near-Miss Corrections", Key: "near_miss_corrections", Description: "Clicks that just missed a target and were corrected. Points at targets that are too small or too close together.", ReportExtractor: humanSignal(func(h *schema.HumanSignals) float64 { return float64(h.Hesitation.NearMissCorrections) }), Category: CategoryHuman, Format: FormatInteger},
+	{Label: "Repeated Targeting", Key: "repeated_targeting", Description: "Repeated clicks on the same target, often because nothing seemed to happen. Points at missing feedback.", ReportExtractor: humanSignal(func(h *schema.HumanSignals) float64 { return float64(h.Hesitation.RepeatedTargeting) }), Category: CategoryHuman, Format: FormatInteger},
 }
@@ -0,0 +1,238 @@
+package format
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"uxbench/schema"
+)
+
+// optionalKeys are the metrics backed by optional schema fields or human
+// signals; testdata/old_report.json carries none of them.
+var optionalKeys = []string{
+	"active_time_ms", "fitts_throughput_ms_bit", "page_scroll_px", "container_scroll_px",
+	"path_efficiency", "overshoots", "scroll_events", "idle_time_ms", "longest_idle_ms",
+	"longest_keyboard_streak", "longest_mouse_streak", "decision_mean_ms", "decision_p90_ms",
+	"hover_hesitations", "near_miss_corrections", "repeated_targeting",
+}
+
+// loadFixture decodes a report from testdata.
+func loadFixture(t *testing.T, name string) *schema.BenchmarkReport {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r schema.BenchmarkReport
+	if err := json.Unmarshal(data, &r); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return &r
+}
+
+// metricDef returns the registry metric with the given key.
+func metricDef(t *testing.T, key string) MetricDef {
+	t.Helper()
+	for _, def := range MetricRegistry {
+		if def.Key == key {
+			return def
+		}
+	}
+	t.Fatalf("no metric with key %q", key)
+	return MetricDef{}
+}
+
+// checkKeys fails t unless every registry key is unique and lowercase snake_case.
+func checkKeys(t *testing.T) {
+	t.Helper()
+	seen := map[string]string{}
+	for _, def := range MetricRegistry {
+		if !IsSnakeKey(def.Key) {
+			t.Errorf("%s: key %q is not lowercase snake_case", def.Label, def.Key)
+		}
+		if other, dup := seen[def.Key]; dup {
+			t.Errorf("key %q is used by both %s and %s", def.Key, other, def.Label)
+		}
+		seen[def.Key] = def.Label
+	}
+}
+
+func TestMetricKeysUniqueSnakeCase(t *testing.T) {
+	checkKeys(t)
+}
+
+func TestKeyAliases(t *testing.T) {
+	keys := map[string]bool{}
+	for _, def := range MetricRegistry {
+		keys[def.Key] = true
+	}
+	for alias, key := range keyAliases {
+		if !IsSnakeKey(alias) {
+			t.Errorf("alias %q is not lowercase snake_case", alias)
+		}
+		if keys[alias] {
+			t.Errorf("alias %q shadows a registry key", alias)
+		}
+		if !keys[key] {
+			t.Errorf("alias %q points at unknown key %q", alias, key)
+		}
+	}
+	if got := CanonicalKey("time_on_task"); got != "time_on_task_ms" {
+		t.Errorf("CanonicalKey(time_on_task) = %q", got)
+	}
+	if got := CanonicalKey("total_clicks"); got != "total_clicks" {
+		t.Errorf("CanonicalKey(total_clicks) = %q", got)
+	}
+}
+
+func TestIsSnakeKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"composite_score", true},
+		{"p90", true},
+		{"decision_p90_ms", true},
+		{"", false},
+		{"Composite_Score", false},
+		{"composite score", false},
+		{"composite-score", false},
+		{"composite__score", false},
+		{"_composite", false},
+		{"composite_", false},
+		{"90th", false},
+	}
+	for _, tt := range tests {
+		if got := IsSnakeKey(tt.key); got != tt.want {
+			t.Errorf("IsSnakeKey(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestRegisterMetricKeys(t *testing.T) {
+	saved := MetricRegistry
+	t.Cleanup(func() { MetricRegistry = saved })
+
+	extract := func(*schema.BenchmarkReport) float64 { return 1 }
+	if err := RegisterMetric(MetricDef{Label: "Clicks per Minute", Key: "clicks_per_minute", ReportExtractor: extract}); err != nil {
+		t.Fatalf("valid metric rejected: %v", err)
+	}
+	checkKeys(t)
+
+	bad := []MetricDef{
+		{Label: "Camel", Key: "clicksPerMinute"},
+		{Label: "Spaced", Key: "clicks per minute"},
+		{Label: "Dashed", Key: "clicks-per-minute"},
+		{Label: "Duplicate", Key: "clicks_per_minute"},
+		{Label: "Builtin", Key: "total_clicks"},
+		{Label: "Alias", Key: "time_on_task"},
+		{Label: "Total Clicks", Key: "total_clicks_again"}, // label clash
+	}
+	for _, def := range bad {
+		def.ReportExtractor = extract
+		if err := RegisterMetric(def); err == nil {
+			t.Errorf("RegisterMetric(%q, %q) succeeded, want an error", def.Label, def.Key)
+		}
+	}
+	checkKeys(t)
+	if len(MetricRegistry) != len(saved)+1 {
+		t.Errorf("registry has %d metrics, want %d", len(MetricRegistry), len(saved)+1)
+	}
+}
+
+func TestOptionalFields(t *testing.T) {
+	if v := optInt(nil); !IsMissing(v) {
+		t.Errorf("optInt(nil) = %v, want Missing", v)
+	}
+	if v := optFloat(nil); !IsMissing(v) {
+		t.Errorf("optFloat(nil) = %v, want Missing", v)
+	}
+	zero, half := 0, 0.5
+	if v := optInt(&zero); v != 0 {
+		t.Errorf("optInt(&0) = %v, want 0", v)
+	}
+	if v := optFloat(&half); v != 0.5 {
+		t.Errorf("optFloat(&0.5) = %v, want 0.5", v)
+	}
+}
+
+func TestIsNil(t *testing.T) {
+	old, modern := loadFixture(t, "old_report.json"), loadFixture(t, "new_report.json")
+	for _, key := range optionalKeys {
+		def := metricDef(t, key)
+		if !def.IsNil(old) {
+			t.Errorf("%s: IsNil(old report) = false, value %v", key, def.Value(old))
+		}
+		if def.IsNil(modern) {
+			t.Errorf("%s: IsNil(new report) = true", key)
+		}
+	}
+	for _, key := range []string{"total_clicks", "time_on_task_ms", "shortcuts_used", "composite_score"} {
+		if metricDef(t, key).IsNil(old) {
+			t.Errorf("%s: IsNil(old report) = true for a required field", key)
+		}
+	}
+}
+
+func TestMissingOutput(t *testing.T) {
+	reports := []*schema.BenchmarkReport{loadFixture(t, "old_report.json"), loadFixture(t, "new_report.json")}
+
+	md := GenerateMarkdownTable(reports)
+	checked := 0
+	for _, key := range optionalKeys {
+		label := metricDef(t, key).Label
+		if !strings.Contains(md, " "+label+" ") {
+			continue // not in the default (non-detail) table
+		}
+		row := ""
+		for _, line := range strings.Split(md, "\n") {
+			if strings.HasPrefix(line, "| "+label+" ") {
+				row = line
+			}
+		}
+		if cells := strings.Split(row, " | "); len(cells) < 3 || cells[1] != "n/a" || cells[2] == "n/a" {
+			t.Errorf("markdown row for %s = %q, want n/a for the old report only", key, row)
+		}
+		checked++
+	}
+	if checked == 0 {
+		t.Error("markdown table shows none of the optional metrics")
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(GenerateCSV(reports))).ReadAll()
+	if err != nil {
+		t.Fatalf("CSV does not parse: %v", err)
+	}
+	csvRows := map[string][]string{}
+	for _, row := range rows {
+		csvRows[row[0]] = row
+	}
+	for _, key := range optionalKeys {
+		row := csvRows[key]
+		if len(row) != 3 || row[1] != "n/a" || row[2] == "n/a" {
+			t.Errorf("CSV row for %s = %q, want n/a for the old report only", key, row)
+		}
+	}
+
+	var doc struct {
+		Products []struct {
+			Metrics map[string]*float64 `json:"metrics"`
+		} `json:"products"`
+	}
+	if err := json.Unmarshal([]byte(GenerateJSON(reports)), &doc); err != nil {
+		t.Fatalf("JSON does not parse: %v", err)
+	}
+	for _, key := range optionalKeys {
+		old, ok := doc.Products[0].Metrics[key]
+		if !ok || old != nil {
+			t.Errorf("JSON %s for the old report = %v (present %v), want null", key, old, ok)
+		}
+		if doc.Products[1].Metrics[key] == nil {
+			t.Errorf("JSON %s for the new report is null", key)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package format
+
+import (
+	"math"
+	"uxbench/schema"
+)
+
+// Options controls how the Markdown and CSV generators lay out a comparison.
+type Options struct {
+	// Transpose renders products as rows and metrics as columns.
+	// Category headers and the trend column are omitted in this layout.
+	Transpose bool
+
+	// Metrics, when set, restricts output to these metrics in this order (see
+	// --metrics). A custom selection is rendered without category headers.
+	Metrics []MetricDef
+
+	// Detail adds the DetailOnly metrics to the registry layout. The detailed
+	// formats (CSV, XLSX) set it themselves.
+	Detail bool
+
+	// SortMetrics orders the metric rows (see --sort-metrics): SortRegistry
+	// ("" too) keeps the registry/selection order under category headers;
+	// SortSpread and SortAlpha render a single untitled group.
+	SortMetrics string
+
+	// Footer adds a row (a column when transposed) with each product's
+	// outright wins and average normalized score (see Grid.Standings).
+	Footer bool
+
+	// GroupBy labels the product columns by persona or agent model instead of
+	// product (see GroupReports). Callers apply it to the reports when loading
+	// them; the generators only read the resulting Product names.
+	GroupBy string
+
+	// SplitEvery, when above 0, splits the Markdown table into several with at
+	// most this many product columns each (see --split-every). Winners are still
+	// computed across all products. The transposed layout is never split.
+	SplitEvery int
+
+	// CSVDelimiter separates CSV cells (see --csv-delimiter); 0 means a comma.
+	// CSVDecimal is the decimal separator of CSV numbers (see --csv-decimal);
+	// 0 means a point. A semicolon and a comma suit spreadsheets set up for
+	// most of Europe.
+	CSVDelimiter rune
+	CSVDecimal   rune
+
+	// CSVEscapeFormulas prefixes CSV text cells (product, task, metadata) that
+	// start with = + - @ or a tab with an apostrophe, so spreadsheets show them
+	// as text instead of running them as formulas (see --csv-escape-formulas).
+	CSVEscapeFormulas bool
+}
+
+// Metric row orders for Options.SortMetrics.
+const (
+	SortRegistry = "registry"
+	SortSpread   = "spread" // most differentiating metric first
+	SortAlpha    = "alpha"  // by label
+)
+
+// SortMetricsModes lists the valid Options.SortMetrics values.
+var SortMetricsModes = []string{SortRegistry, SortSpread, SortAlpha}
+
+// Groups returns the metric groups to render: the registry grouped by category
+// (detail includes DetailOnly metrics), or the selected Metrics as a single
+// untitled group.
+func (o Options) Groups(detail bool) []MetricGroup {
+	if len(o.Metrics) > 0 {
+		return []MetricGroup{{Metrics: o.Metrics}}
+	}
+	return GroupedMetrics(detail)
+}
+
+// Defs is Groups flattened into a single list.
+func (o Options) Defs(detail bool) []MetricDef {
+	var defs []MetricDef
+	for _, group := range o.Groups(detail) {
+		defs = append(defs, group.Metrics...)
+	}
+	return defs
+}
+
+// BestValue returns the winning value of def across reports, respecting HigherIsBetter.
+// Missing values never win; if every report lacks the metric the result is Missing.
+func BestValue(def MetricDef, reports []*schema.BenchmarkReport) float64 {
+	bestVal := Missing
+	for _, r := range reports {
+		val := def.Value(r)
+		if IsMissing(val) {
+			continue
+		}
+		if IsMissing(bestVal) || (def.HigherIsBetter && val > bestVal) || (!def.HigherIsBetter && val < bestVal) {
+			bestVal = val
+		}
+	}
+	return bestVal
+}
+
+// ValueEpsilon is the relative tolerance within which two metric values count as equal.
+const ValueEpsilon = 1e-9
+
+// SameValue reports whether a and b are equal within ValueEpsilon. Missing values never match.
+func SameValue(a, b float64) bool {
+	if IsMissing(a) || IsMissing(b) {
+		return false
+	}
+	return math.Abs(a-b) <= ValueEpsilon*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+// Mark is how one product's value ranks for a metric.
+type Mark int
+
+const (
+	MarkNone   Mark = iota
+	MarkWinner      // the only product with the best value
+	MarkTie         // one of two or more products sharing the best value
+)
+
+// WinnerMarks returns a Mark per report for def: the best value is a MarkWinner
+// when one product holds it and a MarkTie for each product when several do.
+// A single report has nothing to win against and is left unmarked.
+func WinnerMarks(def MetricDef, reports []*schema.BenchmarkReport) []Mark {
+	marks := make([]Mark, len(reports))
+	if len(reports) < 2 {
+		return marks
+	}
+	best := BestValue(def, reports)
+	var atBest []int
+	for i, r := range reports {
+		if SameValue(def.Value(r), best) {
+			atBest = append(atBest, i)
+		}
+	}
+	mark := MarkWinner
+	if len(atBest) > 1 {
+		mark = MarkTie
+	}
+	for _, i := range atBest {
+		marks[i] = mark
+	}
+	return marks
+}
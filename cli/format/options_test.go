@@ -0,0 +1,200 @@
+package format
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"uxbench/schema"
+)
+
+// mixedReports returns an old report without the optional metrics and two
+// newer ones carrying them, the faster of which has 12000ms active time.
+func mixedReports(t *testing.T) []*schema.BenchmarkReport {
+	t.Helper()
+	old, slow, fast := loadFixture(t, "old_report.json"), loadFixture(t, "new_report.json"), loadFixture(t, "new_report.json")
+	active := 12000
+	fast.Metadata.Product = "Modern Fast"
+	fast.Metrics.TimeOnTask.ActiveMS = &active
+	return []*schema.BenchmarkReport{old, slow, fast}
+}
+
+func TestBestValueSkipsMissing(t *testing.T) {
+	reports := mixedReports(t)
+	def := metricDef(t, "active_time_ms")
+
+	if got := BestValue(def, reports); got != 12000 {
+		t.Errorf("BestValue = %v, want 12000", got)
+	}
+	// Missing must not win even for higher-is-better metrics.
+	if got := BestValue(metricDef(t, "path_efficiency"), reports); got != 0.8 {
+		t.Errorf("BestValue(path_efficiency) = %v, want 0.8", got)
+	}
+	if got := BestValue(def, reports[:1]); !IsMissing(got) {
+		t.Errorf("BestValue over old reports only = %v, want Missing", got)
+	}
+}
+
+func TestWinnerMarksSkipMissing(t *testing.T) {
+	reports := mixedReports(t)
+
+	got := WinnerMarks(metricDef(t, "active_time_ms"), reports)
+	if want := []Mark{MarkNone, MarkNone, MarkWinner}; !slices.Equal(got, want) {
+		t.Errorf("active_time_ms marks = %v, want %v", got, want)
+	}
+	// The two new reports share a path efficiency; the old one has none.
+	got = WinnerMarks(metricDef(t, "path_efficiency"), reports)
+	if want := []Mark{MarkNone, MarkTie, MarkTie}; !slices.Equal(got, want) {
+		t.Errorf("path_efficiency marks = %v, want %v", got, want)
+	}
+	old := []*schema.BenchmarkReport{reports[0], loadFixture(t, "old_report.json")}
+	got = WinnerMarks(metricDef(t, "active_time_ms"), old)
+	if want := []Mark{MarkNone, MarkNone}; !slices.Equal(got, want) {
+		t.Errorf("marks with every value missing = %v, want %v", got, want)
+	}
+}
+
+func TestIdleGapsAbsentVersusEmpty(t *testing.T) {
+	def := metricDef(t, "idle_gaps")
+	tests := []struct {
+		name       string
+		timeOnTask string
+		want       float64 // NaN for Missing
+	}{
+		{"absent", `{"total_ms": 1000}`, Missing},
+		{"null", `{"total_ms": 1000, "idle_gaps": null}`, Missing},
+		{"empty", `{"total_ms": 1000, "idle_gaps": []}`, 0},
+		{"two gaps", `{"total_ms": 1000, "idle_gaps": [{"gap_ms": 3000, "after_action": "click", "before_action": "type"}, {"gap_ms": 4000}]}`, 2},
+	}
+	for _, tt := range tests {
+		var r schema.BenchmarkReport
+		if err := json.Unmarshal([]byte(`{"metrics": {"time_on_task": `+tt.timeOnTask+`}}`), &r); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got := def.Value(&r)
+		if IsMissing(tt.want) != IsMissing(got) || (!IsMissing(got) && got != tt.want) {
+			t.Errorf("%s: idle_gaps = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// An empty list is a real zero and can win; an absent one cannot.
+	reports := []*schema.BenchmarkReport{loadFixture(t, "old_report.json"), loadFixture(t, "new_report.json")}
+	if got, want := WinnerMarks(def, reports), []Mark{MarkNone, MarkWinner}; !slices.Equal(got, want) {
+		t.Errorf("idle_gaps marks = %v, want %v", got, want)
+	}
+}
+
+// scoreDef ranks reports by their composite score field, so tests can plant
+// arbitrary values (including negative ones) without a fixture per row.
+func scoreDef(higherIsBetter bool) MetricDef {
+	return MetricDef{
+		Label:           "Score",
+		Key:             "score",
+		HigherIsBetter:  higherIsBetter,
+		ReportExtractor: func(r *schema.BenchmarkReport) float64 { return r.Metrics.CompositeScore },
+	}
+}
+
+func scoreReports(vals ...float64) []*schema.BenchmarkReport {
+	reports := make([]*schema.BenchmarkReport, len(vals))
+	for i, v := range vals {
+		reports[i] = &schema.BenchmarkReport{}
+		reports[i].Metrics.CompositeScore = v
+	}
+	return reports
+}
+
+func TestSameValue(t *testing.T) {
+	tests := []struct {
+		a, b float64
+		want bool
+	}{
+		{1, 1, true},
+		{0, 0, true},
+		{0.1 + 0.2, 0.3, true},
+		{1e12, 1e12 + 1e-3, true}, // relative tolerance for large values
+		{1e-12, 2e-12, true},      // absolute tolerance near zero
+		{0.3, 0.31, false},
+		{1, 1.000001, false},
+		{Missing, Missing, false},
+		{Missing, 0, false},
+	}
+	for _, tt := range tests {
+		if got := SameValue(tt.a, tt.b); got != tt.want {
+			t.Errorf("SameValue(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestWinnerMarksTies(t *testing.T) {
+	tests := []struct {
+		name   string
+		higher bool
+		vals   []float64
+		want   []Mark
+	}{
+		{"clear winner", true, []float64{70, 80, 60}, []Mark{MarkNone, MarkWinner, MarkNone}},
+		{"exact tie", true, []float64{80, 80, 60}, []Mark{MarkTie, MarkTie, MarkNone}},
+		{"near-equal tie", true, []float64{0.1 + 0.2, 0.3, 0.2}, []Mark{MarkTie, MarkTie, MarkNone}},
+		{"near-equal tie, lower is better", false, []float64{0.3, 0.1 + 0.2, 0.5}, []Mark{MarkTie, MarkTie, MarkNone}},
+		{"three-way tie", false, []float64{5, 5, 5}, []Mark{MarkTie, MarkTie, MarkTie}},
+		{"close but distinct", true, []float64{0.3, 0.30001}, []Mark{MarkNone, MarkWinner}},
+		{"single report", true, []float64{80}, []Mark{MarkNone}},
+	}
+	for _, tt := range tests {
+		got := WinnerMarks(scoreDef(tt.higher), scoreReports(tt.vals...))
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: marks = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBestValueNearEqual(t *testing.T) {
+	best := BestValue(scoreDef(true), scoreReports(0.3, 0.1+0.2, 0.2))
+	if !SameValue(best, 0.3) {
+		t.Errorf("BestValue = %v, want 0.3", best)
+	}
+}
+
+func TestMarkdownCellTie(t *testing.T) {
+	tests := []struct {
+		mark Mark
+		want string
+	}{
+		{MarkNone, "42"},
+		{MarkWinner, "**42**"},
+		{MarkTie, "**42** ="},
+	}
+	for _, tt := range tests {
+		if got := markdownCell("42", tt.mark); got != tt.want {
+			t.Errorf("markdownCell(42, %v) = %q, want %q", tt.mark, got, tt.want)
+		}
+	}
+}
+
+func TestWinnerSignedAndZeroRows(t *testing.T) {
+	tests := []struct {
+		name   string
+		higher bool
+		vals   []float64
+		best   float64
+		want   []Mark
+	}{
+		{"all negative, higher is better", true, []float64{-5, -2, -9}, -2, []Mark{MarkNone, MarkWinner, MarkNone}},
+		{"all negative, lower is better", false, []float64{-5, -2, -9}, -9, []Mark{MarkNone, MarkNone, MarkWinner}},
+		{"negative tie", true, []float64{-1.5, -3, -1.5}, -1.5, []Mark{MarkTie, MarkNone, MarkTie}},
+		{"all zero, higher is better", true, []float64{0, 0, 0}, 0, []Mark{MarkTie, MarkTie, MarkTie}},
+		{"all zero, lower is better", false, []float64{0, 0}, 0, []Mark{MarkTie, MarkTie}},
+		{"zero beats negatives", true, []float64{-1, 0, -0.5}, 0, []Mark{MarkNone, MarkWinner, MarkNone}},
+		{"missing first", true, []float64{Missing, -4, -7}, -4, []Mark{MarkNone, MarkWinner, MarkNone}},
+	}
+	for _, tt := range tests {
+		def, reports := scoreDef(tt.higher), scoreReports(tt.vals...)
+		if got := BestValue(def, reports); got != tt.best {
+			t.Errorf("%s: BestValue = %v, want %v", tt.name, got, tt.best)
+		}
+		if got := WinnerMarks(def, reports); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: marks = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"uxbench/schema"
+)
+
+// SchemaVersion is the report's schema version, or "unknown" for reports that
+// don't declare one.
+func SchemaVersion(r *schema.BenchmarkReport) string {
+	if r.SchemaVersion == "" {
+		return "unknown"
+	}
+	return r.SchemaVersion
+}
+
+// SchemaVersions lists the distinct schema versions of reports in first-seen order.
+func SchemaVersions(reports []*schema.BenchmarkReport) []string {
+	var versions []string
+	seen := map[string]bool{}
+	for _, r := range reports {
+		v := SchemaVersion(r)
+		if !seen[v] {
+			seen[v] = true
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
+
+// SchemaVersionLine names the source reports' schema version(s) for a report
+// header, so archived comparisons record what they were built from.
+func SchemaVersionLine(reports []*schema.BenchmarkReport) string {
+	versions := SchemaVersions(reports)
+	if len(versions) == 1 {
+		return "Schema version: " + versions[0]
+	}
+	return "Schema versions: " + strings.Join(versions, ", ")
+}
+
+// MixedSchemaWarning warns when the compared reports use different schema
+// versions, or returns "" when they all agree.
+func MixedSchemaWarning(reports []*schema.BenchmarkReport) string {
+	versions := SchemaVersions(reports)
+	if len(versions) < 2 {
+		return ""
+	}
+	return fmt.Sprintf("Warning: the compared reports use different schema versions (%s); some metrics may not be directly comparable.", strings.Join(versions, ", "))
+}
@@ -0,0 +1,40 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"uxbench/schema"
+)
+
+// LowQuality is the Quality score below which a report is flagged in headers.
+const LowQuality = 0.7
+
+// QualityLabel renders a report's quality score as a percentage, marked ⚠
+// when it is below LowQuality.
+func QualityLabel(r *schema.BenchmarkReport) string {
+	score, _ := r.Quality()
+	return QualityPercent(score)
+}
+
+// QualityPercent renders a quality score as a percentage, marked ⚠ when it is
+// below LowQuality.
+func QualityPercent(score float64) string {
+	s := fmt.Sprintf("%.0f%%", score*100)
+	if score < LowQuality {
+		s += " ⚠"
+	}
+	return s
+}
+
+// QualityWarnings explains each low-quality report, one line per report, so
+// readers know which numbers to treat with care.
+func QualityWarnings(reports []*schema.BenchmarkReport) []string {
+	var out []string
+	for _, r := range reports {
+		score, reasons := r.Quality()
+		if score < LowQuality {
+			out = append(out, fmt.Sprintf("Low quality: %s (%.0f%%): %s", r.Metadata.Product, score*100, strings.Join(reasons, ", ")))
+		}
+	}
+	return out
+}
@@ -0,0 +1,124 @@
+package format
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+	"uxbench/schema"
+)
+
+const (
+	radarWidth  = 880.0 // wider than tall: side labels need the room
+	radarSize   = 640.0
+	radarRadius = 220.0
+	radarRings  = 4
+)
+
+// radarColors are cycled per product.
+var radarColors = []string{"#7D56F4", "#E8590C", "#2B8A3E", "#1C7ED6", "#C2255C", "#F08C00"}
+
+// RadarScore normalizes v to 0..1 across values, flipped so that 1 is always the
+// best value (respecting higherIsBetter). Equal values score 0.5; missing values 0.
+func RadarScore(v float64, values []float64, higherIsBetter bool) float64 {
+	if IsMissing(v) {
+		return 0
+	}
+	min, max := Missing, Missing
+	for _, x := range values {
+		if IsMissing(x) {
+			continue
+		}
+		if IsMissing(min) || x < min {
+			min = x
+		}
+		if IsMissing(max) || x > max {
+			max = x
+		}
+	}
+	if SameValue(max, min) {
+		return 0.5
+	}
+	norm := (v - min) / (max - min)
+	if !higherIsBetter {
+		norm = 1 - norm
+	}
+	return norm
+}
+
+// GenerateRadarSVG draws one polygon per product over the core (non-detail)
+// metrics. Each axis is normalized across the products so the outer edge is
+// the best value on that metric.
+func GenerateRadarSVG(reports []*schema.BenchmarkReport) string {
+	return GenerateRadarSVGWithOptions(reports, Options{})
+}
+
+// GenerateRadarSVGWithOptions is GenerateRadarSVG over opts.Metrics when set.
+func GenerateRadarSVGWithOptions(reports []*schema.BenchmarkReport, opts Options) string {
+	defs := opts.Defs(false)
+
+	cx, cy := radarWidth/2, radarSize/2
+	point := func(axis int, r float64) (float64, float64) {
+		angle := 2*math.Pi*float64(axis)/float64(len(defs)) - math.Pi/2
+		return cx + r*math.Cos(angle), cy + r*math.Sin(angle)
+	}
+
+	var sb strings.Builder
+	legendHeight := 24 * len(reports)
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="system-ui,sans-serif" font-size="11">`+"\n",
+		radarWidth, radarSize+float64(legendHeight), radarWidth, radarSize+float64(legendHeight)))
+	sb.WriteString(`<rect width="100%" height="100%" fill="#fff"/>` + "\n")
+
+	// Grid rings and axes
+	for ring := 1; ring <= radarRings; ring++ {
+		r := radarRadius * float64(ring) / radarRings
+		var pts []string
+		for i := range defs {
+			x, y := point(i, r)
+			pts = append(pts, fmt.Sprintf("%.1f,%.1f", x, y))
+		}
+		sb.WriteString(fmt.Sprintf(`<polygon points="%s" fill="none" stroke="#ddd"/>`+"\n", strings.Join(pts, " ")))
+	}
+	for i, def := range defs {
+		x, y := point(i, radarRadius)
+		sb.WriteString(fmt.Sprintf(`<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#ccc"/>`+"\n", cx, cy, x, y))
+
+		lx, ly := point(i, radarRadius+16)
+		anchor := "middle"
+		if lx < cx-1 {
+			anchor = "end"
+		} else if lx > cx+1 {
+			anchor = "start"
+		}
+		sb.WriteString(fmt.Sprintf(`<text x="%.1f" y="%.1f" text-anchor="%s" dominant-baseline="middle" fill="#444">%s</text>`+"\n",
+			lx, ly, anchor, html.EscapeString(def.Label)))
+	}
+
+	// One polygon per product
+	values := make([][]float64, len(defs))
+	for i, def := range defs {
+		values[i] = make([]float64, len(reports))
+		for j, r := range reports {
+			values[i][j] = def.Value(r)
+		}
+	}
+	labels := ProductLabels(reports)
+	for j, r := range reports {
+		color := radarColors[j%len(radarColors)]
+		var pts []string
+		for i, def := range defs {
+			x, y := point(i, radarRadius*RadarScore(values[i][j], values[i], def.HigherIsBetter))
+			pts = append(pts, fmt.Sprintf("%.1f,%.1f", x, y))
+		}
+		sb.WriteString(fmt.Sprintf(`<polygon points="%s" fill="%s" fill-opacity="0.15" stroke="%s" stroke-width="2"><title>%s</title></polygon>`+"\n",
+			strings.Join(pts, " "), color, color, html.EscapeString(labels[j])))
+
+		ly := radarSize + float64(24*j)
+		sb.WriteString(fmt.Sprintf(`<rect x="24" y="%.0f" width="14" height="14" fill="%s"/>`+"\n", ly, color))
+		sb.WriteString(fmt.Sprintf(`<text x="46" y="%.0f" dominant-baseline="middle" font-size="13">%s — %s</text>`+"\n",
+			ly+7, html.EscapeString(labels[j]), html.EscapeString(r.Metadata.Task)))
+	}
+
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
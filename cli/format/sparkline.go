@@ -0,0 +1,54 @@
+package format
+
+import "uxbench/schema"
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders one block per value, normalized across the values so the
+// tallest block is always the best value (respecting higherIsBetter).
+// Missing values render as a blank.
+func Sparkline(values []float64, higherIsBetter bool) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	min, max := Missing, Missing
+	for _, v := range values {
+		if IsMissing(v) {
+			continue
+		}
+		if IsMissing(min) || v < min {
+			min = v
+		}
+		if IsMissing(max) || v > max {
+			max = v
+		}
+	}
+
+	out := make([]rune, len(values))
+	for i, v := range values {
+		if IsMissing(v) {
+			out[i] = ' '
+			continue
+		}
+		if SameValue(max, min) {
+			out[i] = sparkBlocks[len(sparkBlocks)/2]
+			continue
+		}
+		norm := (v - min) / (max - min)
+		if !higherIsBetter {
+			norm = 1 - norm
+		}
+		out[i] = sparkBlocks[int(norm*float64(len(sparkBlocks)-1)+0.5)]
+	}
+	return string(out)
+}
+
+// MetricSparkline builds the sparkline for a registry metric across reports.
+func MetricSparkline(def MetricDef, reports []*schema.BenchmarkReport) string {
+	values := make([]float64, len(reports))
+	for i, r := range reports {
+		values[i] = def.Value(r)
+	}
+	return Sparkline(values, def.HigherIsBetter)
+}
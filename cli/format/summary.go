@@ -0,0 +1,79 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"uxbench/schema"
+)
+
+// GenerateSummary creates a plain-text summary of a single report.
+func GenerateSummary(r *schema.BenchmarkReport) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("%s — %s\n", r.Metadata.Product, r.Metadata.Task))
+	if r.Metadata.Operator != "" {
+		sb.WriteString(fmt.Sprintf("Operator: %s\n", r.Metadata.Operator))
+	}
+	sb.WriteString("\n")
+
+	// All metrics from shared registry
+	width := 0
+	for _, def := range MetricRegistry {
+		if len(def.Label) > width {
+			width = len(def.Label)
+		}
+	}
+	reports := []*schema.BenchmarkReport{r}
+	for _, group := range GroupedMetrics(true) {
+		if !ShowsGroup(group, reports) {
+			continue
+		}
+		sb.WriteString(group.Category + "\n")
+		for _, def := range group.Metrics {
+			sb.WriteString(fmt.Sprintf("  %-*s  %s\n", width, def.Label, def.FormatValue(def.Value(r))))
+		}
+	}
+
+	if n := len(r.Metrics.TypingRatio.FreeTextFields); n > 0 {
+		sb.WriteString(fmt.Sprintf("\nFree-Text Fields (%d)\n  %s\n", n, FreeTextFieldList(r)))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(HardestTargetsSection(r))
+	sb.WriteString("\n")
+	sb.WriteString(ThroughputSection(r))
+
+	sb.WriteString("\nDetails\n")
+	for _, d := range Details(r) {
+		sb.WriteString(fmt.Sprintf("  %s: %s\n", d.Label, d.Value))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(URLSection(r, nil))
+
+	sb.WriteString("\n")
+	sb.WriteString(IdleGapSection(r))
+	return sb.String()
+}
+
+// IdleGapSection lists the report's longest idle gaps and the worst gap's likely cause.
+func IdleGapSection(r *schema.BenchmarkReport) string {
+	var sb strings.Builder
+	gaps, more := Top(TopIdleGaps(r, 0))
+
+	sb.WriteString(fmt.Sprintf("Idle Gaps (%d)\n", len(r.Metrics.TimeOnTask.IdleGaps)))
+	if t := r.Metrics.TimeOnTask; t.LongestIdleMS != nil && t.LongestIdleAfter != nil {
+		sb.WriteString(fmt.Sprintf("  Longest idle: %.1fs after %q\n", float64(*t.LongestIdleMS)/1000, *t.LongestIdleAfter))
+	}
+	if len(gaps) == 0 {
+		sb.WriteString("  (none)\n")
+	}
+	for _, g := range gaps {
+		sb.WriteString(fmt.Sprintf("  %6.1fs  after %q, before %q\n", g.GapMS/1000, g.AfterAction, g.BeforeAction))
+	}
+	sb.WriteString(MoreLine(more))
+	if cause := WorstIdleCause(r); cause != "" {
+		sb.WriteString(fmt.Sprintf("  Worst gap likely cause: %s\n", cause))
+	}
+	return sb.String()
+}
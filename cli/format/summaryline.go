@@ -0,0 +1,100 @@
+package format
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"uxbench/schema"
+)
+
+// GenerateSummaryLine sums up a comparison in one sentence for commit
+// messages and chat, e.g. "Linear wins 5/8 metrics; best composite 78.20 vs 71.40."
+func GenerateSummaryLine(reports []*schema.BenchmarkReport) string {
+	return GenerateSummaryLineWithOptions(reports, Options{})
+}
+
+// GenerateSummaryLineWithOptions is GenerateSummaryLine counting only the
+// metrics opts selects.
+func GenerateSummaryLineWithOptions(reports []*schema.BenchmarkReport, opts Options) string {
+	grid := BuildComparisonGrid(reports, opts)
+	rows := grid.Rows()
+
+	// Outright wins only; a metric tied for best counts for nobody, and one
+	// no report carries isn't counted at all
+	wins := make([]int, len(grid.Products))
+	total := 0
+	for _, row := range rows {
+		if !slices.ContainsFunc(row.Values, func(v float64) bool { return !IsMissing(v) }) {
+			continue
+		}
+		total++
+		for i, mark := range row.Marks {
+			if mark == MarkWinner {
+				wins[i]++
+			}
+		}
+	}
+
+	line := winsClause(grid.Products, wins, total)
+	if composite := compositeClause(reports); composite != "" {
+		line += "; " + composite
+	}
+	return line + ".\n"
+}
+
+// winsClause names the product with the most metric wins, or the products
+// tied for the most.
+func winsClause(products []string, wins []int, total int) string {
+	best := 0
+	for _, w := range wins {
+		best = max(best, w)
+	}
+	var leaders []string
+	for i, w := range wins {
+		if w == best {
+			leaders = append(leaders, products[i])
+		}
+	}
+
+	switch {
+	case len(leaders) == 1:
+		return fmt.Sprintf("%s wins %d/%d metrics", leaders[0], best, total)
+	case len(leaders) == 2:
+		return fmt.Sprintf("%s and %s tied %d-%d on metrics", leaders[0], leaders[1], best, best)
+	default:
+		return fmt.Sprintf("%s and %s tied at %d/%d metrics each", strings.Join(leaders[:len(leaders)-1], ", "), leaders[len(leaders)-1], best, total)
+	}
+}
+
+// compositeClause compares the two best composite scores, naming the leader
+// when there are more than two products. It is empty when fewer than two
+// reports carry a composite score.
+func compositeClause(reports []*schema.BenchmarkReport) string {
+	var def MetricDef
+	for _, d := range MetricRegistry {
+		if d.Key == "composite_score" {
+			def = d
+		}
+	}
+
+	var scored []*schema.BenchmarkReport
+	for _, r := range reports {
+		if !def.IsNil(r) {
+			scored = append(scored, r)
+		}
+	}
+	if len(scored) < 2 {
+		return ""
+	}
+	sort.SliceStable(scored, func(i, j int) bool { return def.Value(scored[i]) > def.Value(scored[j]) })
+
+	first, second := def.Value(scored[0]), def.Value(scored[1])
+	if SameValue(first, second) {
+		return "composite tied at " + def.FormatValue(first)
+	}
+	if len(reports) == 2 {
+		return fmt.Sprintf("best composite %s vs %s", def.FormatValue(first), def.FormatValue(second))
+	}
+	return fmt.Sprintf("best composite %s (%s) vs %s (%s)", def.FormatValue(first), scored[0].Metadata.Product, def.FormatValue(second), scored[1].Metadata.Product)
+}
@@ -0,0 +1,24 @@
+package format
+
+import "fmt"
+
+// TopN caps how many items every detail list shows (--top): hardest targets,
+// free-text fields, click details, visited pages and idle gaps. 0 shows all.
+var TopN = 3
+
+// Top returns the first TopN items and how many were left out.
+func Top[T any](items []T) ([]T, int) {
+	if TopN <= 0 || len(items) <= TopN {
+		return items, 0
+	}
+	return items[:TopN], len(items) - TopN
+}
+
+// MoreLine is the indented "+K more" line closing a list Top cut short, or ""
+// when nothing was left out.
+func MoreLine(more int) string {
+	if more == 0 {
+		return ""
+	}
+	return fmt.Sprintf("  +%d more\n", more)
+}
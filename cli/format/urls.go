@@ -0,0 +1,64 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"uxbench/schema"
+)
+
+// UniqueURLs lists the distinct pages a recording went through, starting URL
+// first, in visit order. Nil when the report records no URLs.
+func UniqueURLs(r *schema.BenchmarkReport) []string {
+	var urls []string
+	seen := map[string]bool{}
+	for _, u := range append([]string{r.Metadata.URL}, r.Metadata.URLsVisited...) {
+		if u != "" && !seen[u] {
+			seen[u] = true
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// uniqueURLCount is the unique_urls metric: Missing when no URLs were recorded.
+func uniqueURLCount(r *schema.BenchmarkReport) float64 {
+	urls := UniqueURLs(r)
+	if len(urls) == 0 {
+		return Missing
+	}
+	return float64(len(urls))
+}
+
+// URLSection lists r's unique URLs, marking those no other report visited:
+// pages only one product needed for the same task point at a more
+// fragmented flow.
+func URLSection(r *schema.BenchmarkReport, reports []*schema.BenchmarkReport) string {
+	var sb strings.Builder
+	urls := UniqueURLs(r)
+
+	sb.WriteString(fmt.Sprintf("Pages Visited (%d unique)\n", len(urls)))
+	if len(urls) == 0 {
+		sb.WriteString("  (none recorded)\n")
+		return sb.String()
+	}
+
+	elsewhere := map[string]bool{}
+	for _, o := range reports {
+		if o == r {
+			continue
+		}
+		for _, u := range UniqueURLs(o) {
+			elsewhere[u] = true
+		}
+	}
+	shown, more := Top(urls)
+	for _, u := range shown {
+		marker := " "
+		if len(reports) > 1 && !elsewhere[u] {
+			marker = "+"
+		}
+		sb.WriteString(fmt.Sprintf("  %s %s\n", marker, u))
+	}
+	sb.WriteString(MoreLine(more))
+	return sb.String()
+}
@@ -0,0 +1,155 @@
+package format
+
+import (
+	"fmt"
+	"uxbench/schema"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// GenerateXLSX creates an Excel workbook with a styled comparison sheet
+// (bold headers, green winner and amber tie cells, frozen metric column) and a metadata sheet.
+func GenerateXLSX(reports []*schema.BenchmarkReport) ([]byte, error) {
+	return GenerateXLSXWithOptions(reports, Options{})
+}
+
+// GenerateXLSXWithOptions is GenerateXLSX limited to opts.Metrics when set.
+func GenerateXLSXWithOptions(reports []*schema.BenchmarkReport, opts Options) ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	const compSheet, metaSheet = "Comparison", "Metadata"
+	if err := f.SetSheetName("Sheet1", compSheet); err != nil {
+		return nil, err
+	}
+	if _, err := f.NewSheet(metaSheet); err != nil {
+		return nil, err
+	}
+
+	boldStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return nil, err
+	}
+	categoryStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true, Italic: true, Color: "666666"}})
+	if err != nil {
+		return nil, err
+	}
+	winnerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Color: "006100"},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"C6EFCE"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	tieStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Color: "9C5700"},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"FFEB9C"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// --- Comparison sheet (all metrics, like CSV) ---
+	opts.Detail = true
+	grid := BuildComparisonGrid(reports, opts)
+	header := []interface{}{"Metric"}
+	task := []interface{}{"Task"}
+	for p := range grid.Products {
+		header = append(header, grid.Products[p])
+		task = append(task, grid.Tasks[p])
+	}
+	if err := f.SetSheetRow(compSheet, "A1", &header); err != nil {
+		return nil, err
+	}
+	if err := f.SetSheetRow(compSheet, "A2", &task); err != nil {
+		return nil, err
+	}
+	lastCol, _ := excelize.ColumnNumberToName(len(grid.Products) + 1)
+	if err := f.SetCellStyle(compSheet, "A1", lastCol+"1", boldStyle); err != nil {
+		return nil, err
+	}
+
+	row := 3
+	for _, group := range grid.Groups {
+		if group.Category != "" {
+			if err := f.SetCellValue(compSheet, cellName(1, row), group.Category); err != nil {
+				return nil, err
+			}
+			if err := f.SetCellStyle(compSheet, cellName(1, row), cellName(1, row), categoryStyle); err != nil {
+				return nil, err
+			}
+			row++
+		}
+
+		for _, gr := range group.Rows {
+			if err := f.SetCellValue(compSheet, cellName(1, row), gr.Metric.Label); err != nil {
+				return nil, err
+			}
+			for i, val := range gr.Values {
+				cell := cellName(i+2, row)
+				var v interface{} = val
+				if IsMissing(val) {
+					v = "n/a"
+				}
+				if err := f.SetCellValue(compSheet, cell, v); err != nil {
+					return nil, err
+				}
+				style := 0
+				switch gr.Marks[i] {
+				case MarkWinner:
+					style = winnerStyle
+				case MarkTie:
+					style = tieStyle
+				}
+				if style != 0 {
+					if err := f.SetCellStyle(compSheet, cell, cell, style); err != nil {
+						return nil, err
+					}
+				}
+			}
+			row++
+		}
+	}
+
+	if err := f.SetColWidth(compSheet, "A", "A", 30); err != nil {
+		return nil, err
+	}
+	if err := f.SetPanes(compSheet, &excelize.Panes{
+		Freeze:      true,
+		XSplit:      1,
+		YSplit:      1,
+		TopLeftCell: "B2",
+		ActivePane:  "bottomRight",
+	}); err != nil {
+		return nil, err
+	}
+
+	// --- Metadata sheet: one row per report ---
+	metaHeader := []interface{}{"Product", "Task", "Recording", "Operator", "Browser", "Timestamp", "Duration (ms)", "URL", "Source", "Schema Version"}
+	if err := f.SetSheetRow(metaSheet, "A1", &metaHeader); err != nil {
+		return nil, err
+	}
+	metaLast, _ := excelize.ColumnNumberToName(len(metaHeader))
+	if err := f.SetCellStyle(metaSheet, "A1", metaLast+"1", boldStyle); err != nil {
+		return nil, err
+	}
+	for i, r := range reports {
+		md := r.Metadata
+		values := []interface{}{md.Product, md.Task, md.RecordingName, md.Operator, md.Browser, md.Timestamp, md.DurationMS, md.URL, r.Source, r.SchemaVersion}
+		if err := f.SetSheetRow(metaSheet, cellName(1, i+2), &values); err != nil {
+			return nil, err
+		}
+	}
+
+	f.SetActiveSheet(0)
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to write workbook: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func cellName(col, row int) string {
+	name, _ := excelize.CoordinatesToCellName(col, row)
+	return name
+}
@@ -0,0 +1,141 @@
+// Package jsonschema generates a JSON Schema (draft-07) from Go struct types
+// by reflection, so the report contract can't drift from the loader's types.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Draft07 is the $schema URI of generated schemas.
+const Draft07 = "http://json-schema.org/draft-07/schema#"
+
+// Schema is one JSON Schema node. Properties keep struct field order.
+type Schema struct {
+	Schema      string      `json:"$schema,omitempty"`
+	Title       string      `json:"title,omitempty"`
+	Type        interface{} `json:"type,omitempty"` // a type name, or [name, "null"] for pointers
+	Format      string      `json:"format,omitempty"`
+	Required    []string    `json:"required,omitempty"`
+	Properties  *Properties `json:"properties,omitempty"`
+	Items       *Schema     `json:"items,omitempty"`
+	Additional  *Schema     `json:"additionalProperties,omitempty"`
+	Description string      `json:"description,omitempty"`
+}
+
+// Properties is an ordered set of named property schemas.
+type Properties struct {
+	names   []string
+	schemas map[string]*Schema
+}
+
+func (p *Properties) add(name string, s *Schema) {
+	if p.schemas == nil {
+		p.schemas = map[string]*Schema{}
+	}
+	if _, ok := p.schemas[name]; !ok {
+		p.names = append(p.names, name)
+	}
+	p.schemas[name] = s
+}
+
+// MarshalJSON writes the properties in the order they were added.
+func (p *Properties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, name := range p.names {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		val, err := json.Marshal(p.schemas[name])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Marshal returns s as indented JSON ending in a newline, the form `uxbench
+// schema` prints and schema/benchmark.schema.json is committed in.
+func Marshal(s *Schema) ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Generate returns the schema for v's type. Struct fields are named by their
+// json tags; fields tagged "-" are skipped. A field is required only when
+// tagged `jsonschema:"required"`, so older and partial reports the loader
+// reads fine still validate. Pointers also accept null.
+func Generate(v interface{}, title string) *Schema {
+	s := forType(reflect.TypeOf(v))
+	s.Schema = Draft07
+	s.Title = title
+	return s
+}
+
+func forType(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Pointer {
+		s := forType(t.Elem())
+		if name, ok := s.Type.(string); ok {
+			s.Type = []string{name, "null"}
+		}
+		return s
+	}
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: forType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", Additional: forType(t.Elem())}
+	case reflect.Struct:
+		return forStruct(t)
+	}
+	return &Schema{} // interface{}: any value
+}
+
+func forStruct(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: &Properties{}}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		s.Properties.add(name, forType(f.Type))
+		if f.Tag.Get("jsonschema") == "required" {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
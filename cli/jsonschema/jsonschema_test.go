@@ -0,0 +1,149 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"uxbench/schema"
+)
+
+// validate checks doc against the subset of draft-07 that Generate emits
+// (type, required, properties, items, additionalProperties) and returns the
+// violations found.
+func validate(s *Schema, doc interface{}, path string) []string {
+	if !hasType(s.Type, doc) {
+		return []string{fmt.Sprintf("%s: %v is not of type %v", path, doc, s.Type)}
+	}
+	var errs []string
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				errs = append(errs, fmt.Sprintf("%s: missing required %q", path, name))
+			}
+		}
+		for name, val := range v {
+			switch {
+			case s.Properties != nil && s.Properties.schemas[name] != nil:
+				errs = append(errs, validate(s.Properties.schemas[name], val, path+"."+name)...)
+			case s.Additional != nil:
+				errs = append(errs, validate(s.Additional, val, path+"."+name)...)
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				errs = append(errs, validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return errs
+}
+
+func hasType(t interface{}, doc interface{}) bool {
+	var names []string
+	switch t := t.(type) {
+	case nil:
+		return true
+	case string:
+		names = []string{t}
+	case []string:
+		names = t
+	}
+	var got string
+	switch v := doc.(type) {
+	case nil:
+		got = "null"
+	case bool:
+		got = "boolean"
+	case string:
+		got = "string"
+	case float64:
+		if v == float64(int64(v)) && slices.Contains(names, "integer") {
+			return true
+		}
+		got = "number"
+	case []interface{}:
+		got = "array"
+	case map[string]interface{}:
+		got = "object"
+	}
+	return slices.Contains(names, got)
+}
+
+func TestCommittedSchemaUpToDate(t *testing.T) {
+	want, err := Marshal(Generate(schema.BenchmarkReport{}, "UX Benchmark Report"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("../../schema/benchmark.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Error("schema/benchmark.schema.json is out of date; regenerate it from cli/ with `go run . schema -o ../schema/benchmark.schema.json`")
+	}
+}
+
+func TestExamplesValidate(t *testing.T) {
+	s := Generate(schema.BenchmarkReport{}, "UX Benchmark Report")
+	var files []string
+	for _, pattern := range []string{"../../examples/*.json", "../../schema/examples/*.json"} {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		t.Fatal("no example reports found")
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var doc interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		for _, e := range validate(s, doc, "$") {
+			t.Errorf("%s: %s", file, e)
+		}
+	}
+}
+
+func TestRequiredOnlyWhenTagged(t *testing.T) {
+	s := Generate(schema.BenchmarkReport{}, "UX Benchmark Report")
+	if want := []string{"schema_version", "source", "metadata", "metrics"}; !slices.Equal(s.Required, want) {
+		t.Errorf("report requires %v, want %v", s.Required, want)
+	}
+	metadata := s.Properties.schemas["metadata"]
+	if want := []string{"product", "timestamp"}; !slices.Equal(metadata.Required, want) {
+		t.Errorf("metadata requires %v, want %v", metadata.Required, want)
+	}
+	metrics := s.Properties.schemas["metrics"]
+	if want := []string{"composite_score"}; !slices.Equal(metrics.Required, want) {
+		t.Errorf("metrics requires %v, want %v", metrics.Required, want)
+	}
+	timeOnTask := metrics.Properties.schemas["time_on_task"]
+	if slices.Contains(timeOnTask.Required, "idle_gaps") {
+		t.Error("time_on_task.idle_gaps is required")
+	}
+
+	// A minimal report, as older recorders wrote, validates.
+	var doc interface{}
+	minimal := `{"schema_version": "1.0", "source": "human",
+		"metadata": {"product": "p", "timestamp": "2024-01-01T00:00:00Z"},
+		"metrics": {"composite_score": 50, "time_on_task": {"total_ms": 1000}}}`
+	if err := json.Unmarshal([]byte(minimal), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if errs := validate(s, doc, "$"); len(errs) != 0 {
+		t.Errorf("minimal report: %v", errs)
+	}
+}
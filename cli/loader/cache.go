@@ -0,0 +1,71 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"uxbench/schema"
+)
+
+// cacheEntry is a parsed report and the file state it was parsed from.
+type cacheEntry struct {
+	modTime time.Time
+	size    int64
+	report  *schema.BenchmarkReport
+	actions int  // entries in the action log, kept or not
+	partial bool // loaded without its action log (see LoadMetricsOnly)
+}
+
+// cache holds parsed local reports by absolute path, so watch reloads and
+// re-running a comparison from the picker skip files that haven't changed.
+// Callers must treat cached reports as read-only (copy before relabeling, as
+// format.GroupReports does).
+var cache = struct {
+	sync.Mutex
+	entries map[string]cacheEntry
+}{entries: map[string]cacheEntry{}}
+
+// ClearCache forgets every cached report, so the next load re-reads its file.
+func ClearCache() {
+	cache.Lock()
+	defer cache.Unlock()
+	cache.entries = map[string]cacheEntry{}
+}
+
+// cacheKey returns the absolute form of path and its current file info, or
+// ok=false for URLs and files that can't be stat'ed (those aren't cached).
+func cacheKey(path string) (key string, info os.FileInfo, ok bool) {
+	if IsURL(path) {
+		return "", nil, false
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", nil, false
+	}
+	key, err = filepath.Abs(path)
+	if err != nil {
+		return "", nil, false
+	}
+	return key, info, true
+}
+
+// cached returns the report parsed from key if the file's modification time
+// and size are unchanged since. A report cached without its action log only
+// counts when full is false.
+func cached(key string, info os.FileInfo, full bool) (cacheEntry, bool) {
+	cache.Lock()
+	defer cache.Unlock()
+	e, ok := cache.entries[key]
+	if !ok || !e.modTime.Equal(info.ModTime()) || e.size != info.Size() || (full && e.partial) {
+		return cacheEntry{}, false
+	}
+	return e, true
+}
+
+func store(key string, info os.FileInfo, r *schema.BenchmarkReport, actions int, partial bool) {
+	cache.Lock()
+	defer cache.Unlock()
+	cache.entries[key] = cacheEntry{modTime: info.ModTime(), size: info.Size(), report: r, actions: actions, partial: partial}
+}
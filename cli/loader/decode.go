@@ -0,0 +1,172 @@
+package loader
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"uxbench/schema"
+)
+
+// ProgressEvery is how many action log entries are read between calls to
+// LoadOptions.Progress.
+const ProgressEvery = 10000
+
+// LoadOptions controls how much of a report's action log a load keeps. The
+// zero value keeps all of it, like LoadReport.
+type LoadOptions struct {
+	// SkipActionLog reads past the action log without keeping any of it, for
+	// callers that only need the metadata and metrics.
+	SkipActionLog bool
+	// MaxActions keeps at most this many action log entries (0 = all); the
+	// rest are read past but not kept.
+	MaxActions int
+	// Progress, when set, is called with the number of action log entries
+	// read so far, every ProgressEvery entries.
+	Progress func(actions int)
+}
+
+// full reports whether opts keep the whole report, which is what the cache holds.
+func (o LoadOptions) full() bool {
+	return !o.SkipActionLog && o.MaxActions == 0
+}
+
+// trim returns r with its action log cut down to what opts keep. r is shared
+// (e.g. with the cache), so a trimmed report is a shallow copy.
+func (o LoadOptions) trim(r *schema.BenchmarkReport) *schema.BenchmarkReport {
+	if o.full() {
+		return r
+	}
+	c := *r
+	if o.SkipActionLog {
+		c.ActionLog = nil
+	} else if len(c.ActionLog) > o.MaxActions {
+		c.ActionLog = c.ActionLog[:o.MaxActions:o.MaxActions]
+	}
+	return &c
+}
+
+// openReport opens a report file or URL for streaming, after checking that
+// it starts like JSON (see sniff). URLs are fetched whole.
+func openReport(path string) (io.Reader, io.Closer, error) {
+	var src io.Reader
+	closer := io.NopCloser(nil)
+	if IsURL(path) {
+		data, err := fetch(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		src = bytes.NewReader(data)
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read file %s: %w", path, err)
+		}
+		src, closer = f, f
+	}
+
+	br := bufio.NewReaderSize(src, 64*1024)
+	head, _ := br.Peek(sniffLen)
+	if err := sniff(head); err != nil {
+		closer.Close()
+		return nil, nil, fmt.Errorf("%s is not a JSON report: %w", path, err)
+	}
+	if bytes.HasPrefix(head, []byte("\xef\xbb\xbf")) {
+		br.Discard(3)
+	}
+	return br, closer, nil
+}
+
+// decodeReport streams a report from r. Every member except the action log
+// is decoded as usual; the action log is read one entry at a time, keeping
+// only what opts allow, so a huge log is never held in memory as a whole.
+// It also returns how many entries the action log has, kept or not.
+func decodeReport(r io.Reader, opts LoadOptions) (*schema.BenchmarkReport, int, error) {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil {
+		return nil, 0, err
+	} else if tok != json.Delim('{') {
+		return nil, 0, fmt.Errorf("expected an object, got %v", tok)
+	}
+
+	rest := map[string]json.RawMessage{}
+	var log []schema.ActionLogEntry
+	total := 0
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, 0, err
+		}
+		key, _ := tok.(string)
+		if key == "action_log" {
+			if log, total, err = decodeActionLog(dec, opts); err != nil {
+				return nil, 0, fmt.Errorf("action_log: %w", err)
+			}
+			continue
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, 0, err
+		}
+		rest[key] = raw
+	}
+	if _, err := dec.Token(); err != nil { // the closing '}'
+		return nil, 0, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, 0, fmt.Errorf("unexpected data after the report")
+	}
+
+	// The rest is small next to a big action log, so decode it the usual way
+	data, err := json.Marshal(rest)
+	if err != nil {
+		return nil, 0, err
+	}
+	var report schema.BenchmarkReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, 0, err
+	}
+	report.ActionLog = log
+	return &report, total, nil
+}
+
+// decodeActionLog reads the action log array, keeping the entries opts allow.
+func decodeActionLog(dec *json.Decoder, opts LoadOptions) ([]schema.ActionLogEntry, int, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, 0, err
+	}
+	if tok == nil { // null
+		return nil, 0, nil
+	}
+	if tok != json.Delim('[') {
+		return nil, 0, fmt.Errorf("expected an array, got %v", tok)
+	}
+
+	var log []schema.ActionLogEntry
+	n := 0
+	for ; dec.More(); n++ {
+		if opts.Progress != nil && n > 0 && n%ProgressEvery == 0 {
+			opts.Progress(n)
+		}
+		if !opts.SkipActionLog && (opts.MaxActions == 0 || n < opts.MaxActions) {
+			var e schema.ActionLogEntry
+			if err := dec.Decode(&e); err != nil {
+				return nil, 0, err
+			}
+			log = append(log, e)
+			continue
+		}
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return nil, 0, err
+		}
+	}
+	if _, err := dec.Token(); err != nil { // the closing ']'
+		return nil, 0, err
+	}
+	return log, n, nil
+}
@@ -0,0 +1,49 @@
+package loader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"uxbench/schema"
+)
+
+// Duplicate is a report skipped by Dedupe because an earlier file had the same content.
+type Duplicate struct {
+	Path string
+	Of   string
+}
+
+// ContentHash identifies a report by its content, not its filename: the
+// SHA-256 of the parsed report re-encoded as JSON, so formatting and key
+// order differences between copies don't matter.
+func ContentHash(r *schema.BenchmarkReport) (string, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Dedupe keeps the first of each set of reports with identical content,
+// returning the kept paths and reports (still aligned) and the duplicates
+// that were dropped. Reports that can't be hashed are kept.
+func Dedupe(paths []string, reports []*schema.BenchmarkReport) ([]string, []*schema.BenchmarkReport, []Duplicate) {
+	var keptPaths []string
+	var kept []*schema.BenchmarkReport
+	var dups []Duplicate
+	first := map[string]string{} // content hash -> first path
+	for i, r := range reports {
+		if h, err := ContentHash(r); err == nil {
+			if of, seen := first[h]; seen {
+				dups = append(dups, Duplicate{Path: paths[i], Of: of})
+				continue
+			}
+			first[h] = paths[i]
+		}
+		keptPaths = append(keptPaths, paths[i])
+		kept = append(kept, r)
+	}
+	return keptPaths, kept, dups
+}
@@ -0,0 +1,45 @@
+package loader
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HTTPTimeout bounds each report fetched over HTTP (see --timeout).
+var HTTPTimeout = 30 * time.Second
+
+// IsURL reports whether path is an http:// or https:// URL rather than a file.
+func IsURL(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+// fetch downloads a report, insisting on a 200 response with a JSON (or
+// unspecified) content type so HTML error pages don't surface as parse errors.
+func fetch(url string) ([]byte, error) {
+	client := &http.Client{Timeout: HTTPTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", url, resp.Status)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" {
+		mt, _, err := mime.ParseMediaType(ct)
+		if err != nil || !(mt == "application/json" || mt == "text/json" || strings.HasSuffix(mt, "+json")) {
+			return nil, fmt.Errorf("failed to fetch %s: expected JSON, got content type %q", url, ct)
+		}
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", url, err)
+	}
+	return data, nil
+}
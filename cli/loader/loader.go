@@ -1,29 +1,185 @@
 package loader
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
 
+	"uxbench/cli/logging"
 	"uxbench/schema"
 )
 
-// LoadReport reads a JSON file and unmarshals it into a BenchmarkReport
-func LoadReport(path string) (*schema.BenchmarkReport, error) {
+// ReadSource returns the raw bytes of a report file or http(s) URL.
+func ReadSource(path string) ([]byte, error) {
+	if IsURL(path) {
+		return fetch(path)
+	}
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
 	}
+	return data, nil
+}
+
+// LoadReport reads a JSON file (or http(s) URL) and unmarshals it into a BenchmarkReport.
+// Local files are cached until their modification time or size changes (see ClearCache).
+func LoadReport(path string) (*schema.BenchmarkReport, error) {
+	r, _, err := LoadReportWith(path, LoadOptions{})
+	return r, err
+}
+
+// LoadMetricsOnly is LoadReport without the action log: everything else
+// (metadata, metrics, human signals, ...) is decoded, while the action log is
+// only scanned past. Comparisons never look at the action log, and on a report
+// with a long one this is several times faster and holds far less in memory.
+func LoadMetricsOnly(path string) (*schema.BenchmarkReport, error) {
+	r, _, err := LoadReportWith(path, LoadOptions{SkipActionLog: true})
+	return r, err
+}
 
-	var report schema.BenchmarkReport
-	if err := json.Unmarshal(data, &report); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON in %s: %w", path, err)
+// LoadReportWith is LoadReport with control over how much of the action log
+// is kept (see LoadOptions). The file is streamed, so a capped or skipped
+// action log is never held in memory. It also returns how many entries the
+// action log has in total, kept or not. Full and metrics-only loads are
+// cached; a cached full load serves any options.
+func LoadReportWith(path string, opts LoadOptions) (*schema.BenchmarkReport, int, error) {
+	start := time.Now()
+	key, info, cacheable := cacheKey(path)
+	if cacheable {
+		if e, ok := cached(key, info, !opts.SkipActionLog); ok {
+			logging.Debugf("Loaded %s from cache in %s", path, time.Since(start).Round(time.Microsecond))
+			return opts.trim(e.report), e.actions, nil
+		}
+	}
+	report, total, err := parseReport(path, opts)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	// Basic version check
-	if report.SchemaVersion != "1.0" {
-		fmt.Printf("Warning: Schema version %s in file %s may not be fully supported (expected 1.0)\n", report.SchemaVersion, path)
+	if report.SchemaVersion != schema.CurrentSchemaVersion {
+		logging.Warnf("Schema version %s in file %s may not be fully supported (expected %s)", report.SchemaVersion, path, schema.CurrentSchemaVersion)
+	}
+	logging.Debugf("Loaded %s in %s", path, time.Since(start).Round(time.Microsecond))
+
+	if cacheable && (opts.full() || opts.SkipActionLog) {
+		store(key, info, report, total, opts.SkipActionLog)
+	}
+	return report, total, nil
+}
+
+// parseReport streams the report at path through decodeReport.
+func parseReport(path string, opts LoadOptions) (*schema.BenchmarkReport, int, error) {
+	// Reports may arrive without a .json extension, so openReport checks the
+	// content instead and fails fast on anything that clearly isn't JSON.
+	src, closer, err := openReport(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer closer.Close()
+
+	report, total, err := decodeReport(src, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse JSON in %s: %w", path, err)
+	}
+	return report, total, nil
+}
+
+// ReportHeader is the lightweight subset of a report used for quick previews.
+type ReportHeader struct {
+	Metadata       schema.BenchmarkMetadata
+	CompositeScore float64
+	Quality        float64  // see BenchmarkReport.Quality
+	QualityReasons []string // why Quality is below 1
+}
+
+// LoadHeader reads the metadata, composite score and quality of a report.
+// It skips the schema version warning so it can be called from inside a TUI.
+func LoadHeader(path string) (*ReportHeader, error) {
+	if key, info, ok := cacheKey(path); ok {
+		if e, ok := cached(key, info, false); ok {
+			return header(e.report), nil
+		}
+	}
+	// Quality needs the metrics, so decode the report but skip the action log,
+	// which can dwarf everything else.
+	r, _, err := parseReport(path, LoadOptions{SkipActionLog: true})
+	if err != nil {
+		return nil, err
+	}
+	return header(r), nil
+}
+
+func header(r *schema.BenchmarkReport) *ReportHeader {
+	quality, reasons := r.Quality()
+	return &ReportHeader{Metadata: r.Metadata, CompositeScore: r.Metrics.CompositeScore, Quality: quality, QualityReasons: reasons}
+}
+
+// ExpandPaths replaces every directory in paths with the report files it contains
+// (non-recursive, sorted by name; see IsReportFile). Plain file paths and URLs are
+// passed through unchanged.
+func ExpandPaths(paths []string) ([]string, error) {
+	var out []string
+	for _, p := range paths {
+		if IsURL(p) {
+			out = append(out, p)
+			continue
+		}
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", p, err)
+		}
+		if !info.IsDir() {
+			out = append(out, p)
+			continue
+		}
+		entries, err := os.ReadDir(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read directory %s: %w", p, err)
+		}
+		for _, e := range entries {
+			path := filepath.Join(p, e.Name())
+			if !e.IsDir() && !strings.HasPrefix(e.Name(), ".") && IsReportFile(path) {
+				out = append(out, path)
+			}
+		}
+	}
+	return out, nil
+}
+
+// LoadReports loads paths concurrently with a worker pool sized to the number of
+// CPUs. Both returned slices are indexed like paths: reports[i] is nil exactly
+// when errs[i] is set. It is for comparisons, so it loads metrics only (see
+// LoadMetricsOnly).
+func LoadReports(paths []string) ([]*schema.BenchmarkReport, []error) {
+	reports := make([]*schema.BenchmarkReport, len(paths))
+	errs := make([]error, len(paths))
+
+	workers := runtime.NumCPU()
+	if workers > len(paths) {
+		workers = len(paths)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				reports[i], errs[i] = LoadMetricsOnly(paths[i])
+			}
+		}()
+	}
+	for i := range paths {
+		jobs <- i
 	}
+	close(jobs)
+	wg.Wait()
 
-	return &report, nil
+	return reports, errs
 }
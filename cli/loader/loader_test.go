@@ -0,0 +1,241 @@
+package loader
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"uxbench/schema"
+)
+
+// writeReport writes a current-version report for product with an action log
+// of the given length to dir and returns its path.
+func writeReport(tb testing.TB, dir, product string, actions int) string {
+	tb.Helper()
+	r := schema.BenchmarkReport{SchemaVersion: schema.CurrentSchemaVersion, Source: "human"}
+	r.Metadata.Product = product
+	r.Metadata.Task = "Create customer"
+	r.Metrics.ClickCount.Total = actions / 2
+	r.Metrics.CompositeScore = 75
+	for i := 0; i < actions; i++ {
+		x, y := float64(i%1280), float64(i%720)
+		r.ActionLog = append(r.ActionLog, schema.ActionLogEntry{
+			Type:           "click",
+			Timestamp:      1700000000000 + float64(i*250),
+			Target:         fmt.Sprintf("button#save-%d", i%40),
+			Classification: "productive",
+			X:              &x,
+			Y:              &y,
+		})
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	path := filepath.Join(dir, product+".json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		tb.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadReportsKeepsOrder(t *testing.T) {
+	t.Cleanup(ClearCache)
+	dir := t.TempDir()
+	broken := filepath.Join(dir, "broken.json")
+	if err := os.WriteFile(broken, []byte(`{"metadata": {`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	paths := []string{
+		writeReport(t, dir, "alpha", 10),
+		filepath.Join(dir, "missing.json"),
+		writeReport(t, dir, "bravo", 10),
+		broken,
+		writeReport(t, dir, "charlie", 10),
+	}
+	for i := 0; i < 20; i++ {
+		paths = append(paths, writeReport(t, dir, fmt.Sprintf("extra%02d", i), 10))
+	}
+
+	reports, errs := LoadReports(paths)
+	if len(reports) != len(paths) || len(errs) != len(paths) {
+		t.Fatalf("got %d reports and %d errors for %d paths", len(reports), len(errs), len(paths))
+	}
+	for i, path := range paths {
+		bad := path == broken || strings.HasSuffix(path, "missing.json")
+		switch {
+		case bad && errs[i] == nil:
+			t.Errorf("%s: no error", path)
+		case bad && !strings.Contains(errs[i].Error(), filepath.Base(path)):
+			t.Errorf("%s: error %q doesn't name the file", path, errs[i])
+		case bad && reports[i] != nil:
+			t.Errorf("%s: report set alongside error", path)
+		case !bad && errs[i] != nil:
+			t.Errorf("%s: %v", path, errs[i])
+		case !bad && reports[i].Metadata.Product != strings.TrimSuffix(filepath.Base(path), ".json"):
+			t.Errorf("reports[%d] is %s, want the report from %s", i, reports[i].Metadata.Product, path)
+		}
+	}
+}
+
+// BenchmarkLoadReports compares loading one file at a time with the worker
+// pool. The cache is cleared every iteration so each load parses its file.
+func BenchmarkLoadReports(b *testing.B) {
+	dir := b.TempDir()
+	var paths []string
+	for i := 0; i < 32; i++ {
+		paths = append(paths, writeReport(b, dir, fmt.Sprintf("product%02d", i), 2000))
+	}
+	b.Cleanup(ClearCache)
+
+	b.Run("serial", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			ClearCache()
+			for _, path := range paths {
+				if _, err := LoadMetricsOnly(path); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("pooled", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			ClearCache()
+			if _, errs := LoadReports(paths); errs[0] != nil {
+				b.Fatal(errs[0])
+			}
+		}
+	})
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	t.Cleanup(ClearCache)
+	dir := t.TempDir()
+	path := writeReport(t, dir, "alpha", 10)
+
+	load := func() *schema.BenchmarkReport {
+		t.Helper()
+		r, err := LoadReport(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	first := load()
+	if load() != first {
+		t.Fatal("unchanged file was parsed again")
+	}
+
+	// Same content, new modification time.
+	later := time.Now().Add(time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	touched := load()
+	if touched == first {
+		t.Error("cache served a file whose modification time changed")
+	}
+
+	// New content of a different size, with the modification time put back.
+	writeReport(t, dir, "alpha", 20)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	resized := load()
+	if resized == touched || len(resized.ActionLog) != 20 {
+		t.Errorf("cache served a file whose size changed (%d actions, want 20)", len(resized.ActionLog))
+	}
+
+	ClearCache()
+	if load() == resized {
+		t.Error("ClearCache kept the cached report")
+	}
+}
+
+// BenchmarkLoadReportCached compares parsing a report with serving it from
+// the cache, as watch mode and the picker do for unchanged files.
+func BenchmarkLoadReportCached(b *testing.B) {
+	path := writeReport(b, b.TempDir(), "alpha", 5000)
+	b.Cleanup(ClearCache)
+
+	b.Run("uncached", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			ClearCache()
+			if _, err := LoadReport(path); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		ClearCache()
+		for n := 0; n < b.N; n++ {
+			if _, err := LoadReport(path); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestLoadMetricsOnly(t *testing.T) {
+	t.Cleanup(ClearCache)
+	path := writeReport(t, t.TempDir(), "alpha", 300)
+
+	ClearCache()
+	slim, err := LoadMetricsOnly(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slim.ActionLog != nil {
+		t.Errorf("metrics-only load kept %d actions", len(slim.ActionLog))
+	}
+	// A cached metrics-only load must not stand in for a full one.
+	full, err := LoadReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(full.ActionLog) != 300 {
+		t.Fatalf("full load has %d actions, want 300", len(full.ActionLog))
+	}
+	if !reflect.DeepEqual(slim.Metrics, full.Metrics) || slim.Metadata.Product != full.Metadata.Product {
+		t.Error("metrics-only load decoded different metrics or metadata")
+	}
+
+	// A cached full load serves metrics-only callers without losing its log.
+	slim, err = LoadMetricsOnly(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slim.ActionLog != nil || len(full.ActionLog) != 300 {
+		t.Errorf("trimming the cached report: %d actions served, %d left in the cache", len(slim.ActionLog), len(full.ActionLog))
+	}
+}
+
+// BenchmarkLoadMetricsOnly compares a full load with a metrics-only one on a
+// report with a long action log, the case comparisons hit on real recordings.
+func BenchmarkLoadMetricsOnly(b *testing.B) {
+	path := writeReport(b, b.TempDir(), "alpha", 50000)
+	b.Cleanup(ClearCache)
+
+	for _, bench := range []struct {
+		name string
+		load func(string) (*schema.BenchmarkReport, error)
+	}{
+		{"LoadReport", LoadReport},
+		{"LoadMetricsOnly", LoadMetricsOnly},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				ClearCache()
+				if _, err := bench.load(path); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package loader
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// sniffLen is how much of a file is read to decide whether it holds JSON.
+const sniffLen = 512
+
+// sniff checks that data starts like a JSON report: optional BOM and
+// whitespace, then '{'. Anything with a NUL byte in it is treated as binary.
+func sniff(data []byte) error {
+	head := data[:min(len(data), sniffLen)]
+	if bytes.IndexByte(head, 0) >= 0 {
+		return fmt.Errorf("binary content")
+	}
+	head = bytes.TrimLeft(bytes.TrimPrefix(head, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if len(head) == 0 {
+		return fmt.Errorf("empty file")
+	}
+	if head[0] != '{' {
+		return fmt.Errorf("content starts with %q, not '{'", head[0])
+	}
+	return nil
+}
+
+// LooksLikeJSON reports whether the file at path starts like a JSON report,
+// reading only its first few hundred bytes.
+func LooksLikeJSON(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	buf := make([]byte, sniffLen)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return false
+	}
+	return sniff(buf[:n]) == nil
+}
+
+// IsReportFile reports whether a file belongs in a report listing: a .json
+// file, or an extensionless one (e.g. a download saved without its
+// extension) whose content looks like JSON.
+func IsReportFile(path string) bool {
+	name := filepath.Base(path)
+	if strings.HasSuffix(name, ".json") {
+		return true
+	}
+	return filepath.Ext(name) == "" && LooksLikeJSON(path)
+}
@@ -0,0 +1,69 @@
+// Package logging writes diagnostics (warnings, notices, load timings) to
+// stderr, so stdout carries only the report content a command was asked for
+// and can be piped safely.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Level controls which messages are written.
+type Level int
+
+const (
+	Quiet   Level = iota // nothing but errors, which commands return rather than log
+	Normal               // warnings and notices (the default)
+	Verbose              // also debug detail such as per-file load timings
+)
+
+var (
+	mu    sync.Mutex // reports load concurrently
+	level            = Normal
+	out   io.Writer  = os.Stderr
+)
+
+// SetLevel sets the minimum level messages need to be written (--quiet, --verbose).
+func SetLevel(l Level) {
+	mu.Lock()
+	defer mu.Unlock()
+	level = l
+}
+
+// SetOutput redirects messages, e.g. away from a running TUI.
+func SetOutput(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	out = w
+}
+
+// Warnf reports a recoverable problem, prefixed with "Warning: ".
+func Warnf(format string, args ...any) {
+	logf(Normal, "Warning: "+format, args...)
+}
+
+// Infof reports progress or a result written somewhere other than stdout.
+func Infof(format string, args ...any) {
+	logf(Normal, format, args...)
+}
+
+// Debugf reports detail only shown with --verbose.
+func Debugf(format string, args ...any) {
+	logf(Verbose, format, args...)
+}
+
+func logf(l Level, format string, args ...any) {
+	mu.Lock()
+	defer mu.Unlock()
+	if level < l {
+		return
+	}
+	msg := fmt.Sprintf(format, args...)
+	if !strings.HasSuffix(msg, "\n") {
+		msg += "\n"
+	}
+	fmt.Fprint(out, msg)
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"uxbench/cli/format"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// chartWidth is the length in cells of the longest bar.
+const chartWidth = 40
+
+var barStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("99"))
+
+// chartDefs are the metrics the chart cycles through: the selection, or the
+// full registry (without Human Signals when no report has them).
+func (m ResultsModel) chartDefs() []format.MetricDef {
+	var defs []format.MetricDef
+	for _, group := range m.opts.Groups(true) {
+		if format.ShowsGroup(group, m.shown()) {
+			defs = append(defs, group.Metrics...)
+		}
+	}
+	return defs
+}
+
+// cycleChartMetric moves the charted metric by delta through chartDefs.
+func (m ResultsModel) cycleChartMetric(delta int) ResultsModel {
+	n := len(m.chartDefs())
+	m.chartMetric = ((m.chartMetric+delta)%n + n) % n
+	return m
+}
+
+// chartView draws one horizontal bar per product for the selected metric,
+// scaled to the largest value and with the winner highlighted.
+func (m ResultsModel) chartView() string {
+	defs := m.chartDefs()
+	def := defs[m.chartMetric]
+	marks := format.WinnerMarks(def, m.shown())
+
+	maxVal := 0.0
+	labelWidth := 0
+	labels := format.ProductLabels(m.shown())
+	for i, r := range m.shown() {
+		if v := def.Value(r); !format.IsMissing(v) {
+			maxVal = math.Max(maxVal, math.Abs(v))
+		}
+		labelWidth = max(labelWidth, lipgloss.Width(labels[i]))
+	}
+
+	var s strings.Builder
+	s.WriteString("\n")
+	s.WriteString(resultsTitleStyle.Render(fmt.Sprintf(" %s ", def.Label)))
+	s.WriteString(categoryStyle.Render(fmt.Sprintf("  %s • %d/%d", def.Category, m.chartMetric+1, len(defs))))
+	s.WriteString("\n\n")
+
+	for i, r := range m.shown() {
+		val := def.Value(r)
+		n := 0
+		if maxVal > 0 && !format.IsMissing(val) {
+			n = int(math.Round(math.Abs(val) / maxVal * chartWidth))
+		}
+		// Keep non-zero values visible
+		if n == 0 && val != 0 && !format.IsMissing(val) {
+			n = 1
+		}
+
+		style := barStyle
+		if len(m.shown()) > 1 {
+			switch marks[i] {
+			case format.MarkWinner:
+				style = winnerStyle
+			case format.MarkTie:
+				style = tieStyle
+			}
+		}
+		s.WriteString(fmt.Sprintf("  %-*s  %s %s\n",
+			labelWidth, labels[i],
+			style.Render(strings.Repeat("█", n)),
+			def.FormatValue(val),
+		))
+	}
+
+	direction := "lower is better"
+	if def.HigherIsBetter {
+		direction = "higher is better"
+	}
+	s.WriteString("\n  " + categoryStyle.Render(fmt.Sprintf("(%s • ←/→: Metric • b: Table)", direction)) + "\n")
+	return s.String()
+}
@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"uxbench/cli/format"
+	"uxbench/schema"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// maxHeaderWidth caps the product and task cells of the table, in terminal
+// columns, so one long name can't push the other products off screen.
+const maxHeaderWidth = 24
+
+// fitHeader shortens s to maxHeaderWidth columns with an ellipsis, counting
+// wide (CJK, emoji) runes as two, and reports whether it had to.
+func fitHeader(s string) (string, bool) {
+	if runewidth.StringWidth(s) <= maxHeaderWidth {
+		return s, false
+	}
+	return runewidth.Truncate(s, maxHeaderWidth, "…"), true
+}
+
+// fullNamesLine spells out the product and task names fitHeader shortened in
+// the table header, or returns "" when none were.
+func fullNamesLine(products, tasks []string) string {
+	var full []string
+	for i, p := range products {
+		_, cutP := fitHeader(p)
+		_, cutT := fitHeader(tasks[i])
+		if !cutP && !cutT {
+			continue
+		}
+		name := p
+		if tasks[i] != "" {
+			name += " — " + tasks[i]
+		}
+		full = append(full, name)
+	}
+	if len(full) == 0 {
+		return ""
+	}
+	return metaStyle.Render("  Full names: " + strings.Join(full, " · "))
+}
+
+// column returns the load position of m.reports[i], which identifies its
+// column across sorts and watch reloads. Product and recording name can't:
+// CSV imports of one product and --no-dedup copies share both.
+func (m ResultsModel) column(i int) int {
+	if i < len(m.columns) {
+		return m.columns[i]
+	}
+	return i
+}
+
+// shown returns the reports whose columns aren't hidden, in display order.
+// Everything rendered (winners included) is computed over these.
+func (m ResultsModel) shown() []*schema.BenchmarkReport {
+	if len(m.hidden) == 0 {
+		return m.reports
+	}
+	var out []*schema.BenchmarkReport
+	for i, r := range m.reports {
+		if !m.hidden[m.column(i)] {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// toggleColumn hides or shows the n-th product (1-based, in m.reports order),
+// keeping at least two columns to compare.
+func (m ResultsModel) toggleColumn(n int) ResultsModel {
+	if n < 1 || n > len(m.reports) {
+		return m
+	}
+	k := m.column(n - 1)
+	if !m.hidden[k] && len(m.shown()) <= 2 {
+		m.SaveMsg = "Error: at least two products must stay visible"
+		return m
+	}
+	hidden := map[int]bool{}
+	for h := range m.hidden {
+		hidden[h] = true
+	}
+	if hidden[k] {
+		delete(hidden, k)
+	} else {
+		hidden[k] = true
+	}
+	m.hidden = hidden
+	m.SaveMsg = ""
+	return m
+}
+
+// columnsLine lists the products with the number that toggles each; hidden
+// ones are struck through. It is empty when there is nothing to narrow.
+func (m ResultsModel) columnsLine() string {
+	if len(m.reports) < 3 && len(m.hidden) == 0 {
+		return ""
+	}
+	var parts []string
+	labels := format.ProductLabels(m.reports)
+	for i := range m.reports {
+		if i >= 9 {
+			break // only 1-9 have keys
+		}
+		label := fmt.Sprintf("%d %s", i+1, labels[i])
+		if m.hidden[m.column(i)] {
+			label = hiddenColumnStyle.Render(label)
+		}
+		parts = append(parts, label)
+	}
+	hint := "  Columns (1-9: hide/show"
+	if len(m.hidden) > 0 {
+		hint += ", 0: show all"
+	}
+	return metaStyle.Render(hint+"): ") + metaStyle.Render(strings.Join(parts, " · "))
+}
@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"uxbench/cli/format"
+	"uxbench/schema"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestFitHeader(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+		cut            bool
+	}{
+		{"short", "Acme", "Acme", false},
+		{"exactly the limit", strings.Repeat("a", maxHeaderWidth), strings.Repeat("a", maxHeaderWidth), false},
+		{"one over", strings.Repeat("a", maxHeaderWidth+1), strings.Repeat("a", maxHeaderWidth-1) + "…", true},
+		{"CJK at the limit", strings.Repeat("漢", maxHeaderWidth/2), strings.Repeat("漢", maxHeaderWidth/2), false},
+		{"CJK over", strings.Repeat("漢", maxHeaderWidth/2+1), strings.Repeat("漢", maxHeaderWidth/2-1) + "…", true},
+		// The wide rune would straddle the limit, so it is dropped whole.
+		{"CJK straddling", strings.Repeat("a", maxHeaderWidth-1) + "漢", strings.Repeat("a", maxHeaderWidth-1) + "…", true},
+		{"emoji at the limit", strings.Repeat("a", maxHeaderWidth-2) + "🚀", strings.Repeat("a", maxHeaderWidth-2) + "🚀", false},
+		{"emoji over", strings.Repeat("🚀", maxHeaderWidth/2) + "!", strings.Repeat("🚀", maxHeaderWidth/2-1) + "…", true},
+		{"mixed", "日本語のプロダクト名 Enterprise", "日本語のプロダクト名 En…", true},
+	}
+	for _, tt := range tests {
+		got, cut := fitHeader(tt.in)
+		if got != tt.want || cut != tt.cut {
+			t.Errorf("%s: fitHeader(%q) = %q, %v; want %q, %v", tt.name, tt.in, got, cut, tt.want, tt.cut)
+		}
+		if w := runewidth.StringWidth(got); w > maxHeaderWidth {
+			t.Errorf("%s: %q is %d columns wide, over %d", tt.name, got, w, maxHeaderWidth)
+		}
+	}
+}
+
+func TestFullNamesLine(t *testing.T) {
+	long := strings.Repeat("漢", maxHeaderWidth/2+1)
+	tests := []struct {
+		name            string
+		products, tasks []string
+		want            string // "" for no line
+	}{
+		{"nothing cut", []string{"Acme", "Globex"}, []string{"Checkout", "Checkout"}, ""},
+		{"long product", []string{long, "Globex"}, []string{"Checkout", "Checkout"}, "Full names: " + long + " — Checkout"},
+		{"long task", []string{"Acme", "Globex"}, []string{"Checkout", "Checkout with a very long task name"}, "Full names: Globex — Checkout with a very long task name"},
+		{"no task", []string{long, "Globex"}, []string{"", ""}, "Full names: " + long},
+		{"two cut", []string{long, "Globex"}, []string{"Checkout", "Checkout with a very long task name"},
+			"Full names: " + long + " — Checkout · Globex — Checkout with a very long task name"},
+	}
+	for _, tt := range tests {
+		got := fullNamesLine(tt.products, tt.tasks)
+		if tt.want == "" {
+			if got != "" {
+				t.Errorf("%s: fullNamesLine = %q, want none", tt.name, got)
+			}
+			continue
+		}
+		if !strings.Contains(got, tt.want) {
+			t.Errorf("%s: fullNamesLine = %q, want it to contain %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// sameNameReports returns reports that share a product and recording name,
+// as CSV imports of one product and --no-dedup copies do.
+func sameNameReports(clicks ...int) []*schema.BenchmarkReport {
+	reports := make([]*schema.BenchmarkReport, len(clicks))
+	for i, c := range clicks {
+		r := &schema.BenchmarkReport{}
+		r.Metadata.Product = "Acme"
+		r.Metadata.RecordingName = "run"
+		r.Metrics.ClickCount.Total = c
+		reports[i] = r
+	}
+	return reports
+}
+
+func TestToggleColumnSameNames(t *testing.T) {
+	reports := sameNameReports(10, 20, 30)
+	m := NewResultsModel(reports).toggleColumn(1)
+	if shown := m.shown(); len(shown) != 2 || shown[0] != reports[1] || shown[1] != reports[2] {
+		t.Fatalf("hiding column 1 left %d columns shown", len(shown))
+	}
+	m = m.toggleColumn(2)
+	if len(m.shown()) != 2 || m.SaveMsg == "" {
+		t.Errorf("hid a second of three columns: %d shown, message %q", len(m.shown()), m.SaveMsg)
+	}
+	if m = m.toggleColumn(1); len(m.shown()) != 3 {
+		t.Errorf("showing column 1 again left %d columns shown", len(m.shown()))
+	}
+
+	// The same file passed twice with --no-dedup is one cached report.
+	dup := []*schema.BenchmarkReport{reports[0], reports[0], reports[1]}
+	if shown := NewResultsModel(dup).toggleColumn(2).shown(); len(shown) != 2 {
+		t.Errorf("hiding one of two identical columns left %d shown", len(shown))
+	}
+}
+
+func TestHiddenColumnSurvivesSort(t *testing.T) {
+	reports := sameNameReports(30, 10, 20)
+	m := NewResultsModel(reports).toggleColumn(1) // hide the 30-click run
+
+	var clicks format.MetricDef
+	for _, def := range format.MetricRegistry {
+		if def.Key == "total_clicks" {
+			clicks = def
+		}
+	}
+	m = m.sortedBy(clicks)
+	if m.reports[2] != reports[0] {
+		t.Fatalf("sort by clicks put the 30-click run at %v", m.reports)
+	}
+	shown := m.shown()
+	if len(shown) != 2 || shown[0] != reports[1] || shown[1] != reports[2] {
+		t.Errorf("after sorting, shown = %v, want the 10- and 20-click runs", shown)
+	}
+
+	// A watch reload brings fresh pointers in load order.
+	fresh := sameNameReports(30, 10, 20)
+	m.sortKey = clicks.Key
+	shown = m.withReports(fresh).shown()
+	if len(shown) != 2 || shown[0] != fresh[1] || shown[1] != fresh[2] {
+		t.Errorf("after reload, shown = %v, want the 10- and 20-click runs", shown)
+	}
+}
@@ -0,0 +1,28 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"uxbench/cli/format"
+)
+
+// detailsView shows per-product diagnostics that don't fit the metric grid:
+// the hardest Fitts targets (worst first), the descriptive detail lines and
+// the pages each product went through.
+func (m ResultsModel) detailsView() string {
+	var s strings.Builder
+	s.WriteString("\n")
+	s.WriteString(resultsTitleStyle.Render(" Details "))
+	s.WriteString("\n")
+	labels := format.ProductLabels(m.shown())
+	for i, r := range m.shown() {
+		s.WriteString("\n" + headerStyle.Render(labels[i]) + "\n")
+		s.WriteString(format.HardestTargetsSection(r))
+		for _, d := range format.Details(r) {
+			s.WriteString(fmt.Sprintf("%s %s\n", categoryStyle.Render(d.Label+":"), d.Value))
+		}
+		s.WriteString(format.URLSection(r, m.shown()))
+	}
+	s.WriteString("\n  " + categoryStyle.Render("(▶ hardest to hit • + only this product visited • d: Table)") + "\n")
+	return s.String()
+}
@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"uxbench/cli/format"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// drillKeys are the metric rows Enter can drill into: the click counts open
+// the ceremonial and wasted click details.
+var drillKeys = map[string]bool{
+	"total_clicks":      true,
+	"click_efficiency":  true,
+	"productive_clicks": true,
+	"ceremonial_clicks": true,
+	"wasted_clicks":     true,
+}
+
+// drillChrome is the number of view lines around the drill-down viewport
+// (title above, hint below), left out of its height.
+const drillChrome = 6
+
+// cursorRow is the metric row under the table cursor.
+func (m ResultsModel) cursorRow() (format.GridRow, bool) {
+	rows := format.BuildComparisonGrid(m.shown(), m.opts).Rows()
+	if m.cursor < 0 || m.cursor >= len(rows) {
+		return format.GridRow{}, false
+	}
+	return rows[m.cursor], true
+}
+
+// moveCursor moves the table cursor by delta, staying on a metric row.
+func (m ResultsModel) moveCursor(delta int) ResultsModel {
+	n := len(format.BuildComparisonGrid(m.shown(), m.opts).Rows())
+	m.cursor = max(0, min(n-1, m.cursor+delta))
+	return m.scrollToCursor()
+}
+
+// openDrill shows the drill-down panel for the row under the cursor, if it has one.
+func (m ResultsModel) openDrill() ResultsModel {
+	row, ok := m.cursorRow()
+	if !ok {
+		return m
+	}
+	if !drillKeys[row.Metric.Key] {
+		m.SaveMsg = fmt.Sprintf("No drill-down for %s; press d for per-product details", row.Metric.Label)
+		return m
+	}
+
+	var s strings.Builder
+	labels := format.ProductLabels(m.shown())
+	for i, r := range m.shown() {
+		if i > 0 {
+			s.WriteString("\n")
+		}
+		s.WriteString(headerStyle.Render(labels[i]) + "\n")
+		s.WriteString(format.ClickDetailsSection(r))
+	}
+	return m.showPanel(" Click Drill-Down ", s.String())
+}
+
+// openExplain shows what the metric under the cursor measures (see format.Explain).
+func (m ResultsModel) openExplain() ResultsModel {
+	row, ok := m.cursorRow()
+	if !ok {
+		return m
+	}
+	return m.showPanel(" About This Metric ", format.Explain(row.Metric))
+}
+
+// showPanel opens the scrollable drill-down panel with content under title.
+func (m ResultsModel) showPanel(title, content string) ResultsModel {
+	height := m.height - drillChrome
+	if height < 5 {
+		height = 20 // size unknown yet
+	}
+	m.drill = viewport.New(m.width, height)
+	m.drill.SetContent(content)
+	m.drillTitle = title
+	m.view = viewDrill
+	return m
+}
+
+// updateDrill scrolls the drill-down panel; esc (or enter, or i) closes it.
+func (m ResultsModel) updateDrill(msg tea.Msg) (ResultsModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, resultsKeys.Back), key.Matches(msg, resultsKeys.Drill), key.Matches(msg, resultsKeys.Explain):
+			m.view = viewTable
+			return m, nil
+		case key.Matches(msg, resultsKeys.Quit):
+			m.quitting = true
+			return m, tea.Quit
+		}
+	}
+	var cmd tea.Cmd
+	m.drill, cmd = m.drill.Update(msg)
+	return m, cmd
+}
+
+func (m ResultsModel) drillView() string {
+	var s strings.Builder
+	s.WriteString("\n")
+	s.WriteString(resultsTitleStyle.Render(m.drillTitle))
+	s.WriteString("\n\n")
+	s.WriteString(m.drill.View())
+	s.WriteString(fmt.Sprintf("\n\n  %s\n", categoryStyle.Render(fmt.Sprintf("(↑/↓ scroll • %3.f%% • esc: Table)", m.drill.ScrollPercent()*100))))
+	return s.String()
+}
@@ -3,9 +3,11 @@ package tui
 import (
 	"fmt"
 	"strings"
-	"uxbench/cli/loader"
+	"uxbench/cli/format"
 	"uxbench/schema"
 
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -15,6 +17,7 @@ type FlowState int
 const (
 	StatePicking FlowState = iota
 	StateLoading
+	StateSelectingMetrics
 	StateResults
 )
 
@@ -22,15 +25,23 @@ type CompareFlowModel struct {
 	state   FlowState
 	picker  Model
 	results ResultsModel
+	load    loadState
+	metrics metricSelectModel
+
+	// Options apply to the results view (e.g. a --metrics selection)
+	Options format.Options
+
 	width   int
 	height  int
 	err     error
 }
 
-func NewCompareFlowModel() CompareFlowModel {
+// NewCompareFlowModel starts the picker -> results flow. maxSelected caps the
+// number of files the picker accepts (0 = unlimited).
+func NewCompareFlowModel(maxSelected int) CompareFlowModel {
 	return CompareFlowModel{
 		state:  StatePicking,
-		picker: NewModel(),
+		picker: NewModelWithLimit(maxSelected),
 		// Results initialized empty
 	}
 }
@@ -47,20 +58,31 @@ func (m CompareFlowModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		m.picker.list.SetSize(msg.Width, msg.Height-4)
+		m.picker.setSize(msg.Width, msg.Height)
+		m.results = m.results.setSize(msg.Width, msg.Height)
 		return m, nil
 	
-	case reportsLoadedMsg:
-		m.results = NewResultsModel(msg)
-		m.state = StateResults
+	case fileLoadedMsg:
+		if m.state != StateLoading || msg.gen != m.load.gen {
+			return m, nil // canceled load
+		}
+		// Proceed past files that failed as long as enough loaded; the
+		// failures stay listed in the results footer.
+		if m.load.record(msg) && len(m.load.loaded()) >= m.picker.MinSelected {
+			return m.selectMetrics()
+		}
 		return m, nil
 
-	case errMsg:
-		m.err = msg
-		return m, nil // Show error view
+	case spinner.TickMsg:
+		if m.state != StateLoading || m.load.finished() {
+			return m, nil
+		}
+		m.load.spinner, cmd = m.load.spinner.Update(msg)
+		return m, cmd
 	
 	case tea.KeyMsg:
 		if msg.String() == "ctrl+c" {
+			saveLastDir(m.picker.currentDir)
 			return m, tea.Quit
 		}
 	}
@@ -68,21 +90,12 @@ func (m CompareFlowModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch m.state {
 	case StatePicking:
 		// Intercept 'c' for transition
-		if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "c" {
-			if len(m.picker.SelectedPaths) >= 2 {
+		if msg, ok := msg.(tea.KeyMsg); ok && key.Matches(msg, pickerKeys.Compare) && !m.picker.showHelp {
+			if len(m.picker.SelectedPaths) >= m.picker.MinSelected {
 				m.state = StateLoading
-				return m, func() tea.Msg {
-					// Async loader
-					reports := make([]*schema.BenchmarkReport, len(m.picker.SelectedPaths))
-					for i, p := range m.picker.SelectedPaths {
-						r, err := loader.LoadReport(p)
-						if err != nil {
-							return errMsg(err)
-						}
-						reports[i] = r
-					}
-					return reportsLoadedMsg(reports)
-				}
+				saveLastDir(m.picker.currentDir)
+				m.load, cmd = startLoad(m.load.gen+1, m.picker.SelectedPaths)
+				return m, cmd
 			}
 		}
 
@@ -96,13 +109,48 @@ func (m CompareFlowModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		
 		cmd = newCmd
 
-	case StateResults:
+	case StateLoading:
 		if msg, ok := msg.(tea.KeyMsg); ok {
 			switch msg.String() {
-			case "q":
-				return m, tea.Quit
 			case "esc", "backspace":
-				m.state = StatePicking
+				// Cancel: late results carry a stale gen and are dropped
+				m.load.gen++
+				return m.backToPicker()
+			}
+		}
+
+	case StateSelectingMetrics:
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			switch {
+			case key.Matches(msg, resultsKeys.Quit):
+				saveLastDir(m.picker.currentDir)
+				return m, tea.Quit
+			case key.Matches(msg, resultsKeys.Back):
+				return m.backToPicker()
+			}
+			var confirmed bool
+			if m.metrics, confirmed = m.metrics.update(msg); confirmed {
+				return m.showResults(m.load.loaded())
+			}
+		}
+
+	case StateResults:
+		if msg, ok := msg.(tea.MouseMsg); ok && !m.results.ShowingDrillDown() {
+			// Map rows against the whole frame, which includes the footer below the results
+			if isClick(msg) {
+				m.results = m.results.clickAt(frameLine(msg.Y, m.View(), m.height))
+			}
+			return m, nil
+		}
+		// While the save prompt is open, every key belongs to it
+		if msg, ok := msg.(tea.KeyMsg); ok && !m.results.Prompting() && !m.results.ShowingHelp() {
+			switch {
+			case key.Matches(msg, resultsKeys.Quit):
+				saveLastDir(m.picker.currentDir)
+				return m, tea.Quit
+			case key.Matches(msg, resultsKeys.Back) && !m.results.ShowingDrillDown():
+				// Keep the checklist as it was so the user can adjust it
+				m.state = StateSelectingMetrics
 				return m, nil
 			}
 		}
@@ -115,9 +163,36 @@ func (m CompareFlowModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-// Custom Messages
-type reportsLoadedMsg []*schema.BenchmarkReport
-type errMsg error
+// backToPicker returns to file selection keeping the picker's directory and
+// selections, so the user can tweak the comparison and re-run it.
+func (m CompareFlowModel) backToPicker() (CompareFlowModel, tea.Cmd) {
+	m.state = StatePicking
+	m.err = nil
+	// Re-read the directory in case files changed while viewing results;
+	// reload re-applies SelectedPaths so the checkmarks stay in sync.
+	return m, m.picker.reload()
+}
+
+// selectMetrics shows the metric checklist for the loaded reports, pre-checked
+// to the --metrics selection or else the default layout.
+func (m CompareFlowModel) selectMetrics() (CompareFlowModel, tea.Cmd) {
+	m.metrics = newMetricSelect(m.Options.Metrics)
+	m.state = StateSelectingMetrics
+	return m, nil
+}
+
+func (m CompareFlowModel) showResults(reports []*schema.BenchmarkReport) (CompareFlowModel, tea.Cmd) {
+	opts := m.Options
+	// The default set keeps the category layout; anything else is a custom selection
+	opts.Metrics = nil
+	if len(m.Options.Metrics) > 0 || !m.metrics.isDefault() {
+		opts.Metrics = m.metrics.selected()
+	}
+	m.results = NewResultsModelWithOptions(format.GroupReports(reports, opts.GroupBy), opts)
+	m.results = m.results.setSize(m.width, m.height)
+	m.state = StateResults
+	return m, nil
+}
 
 func (m CompareFlowModel) View() string {
 	if m.err != nil {
@@ -128,20 +203,29 @@ func (m CompareFlowModel) View() string {
 	case StatePicking:
 		return m.picker.View()
 	case StateLoading:
-		return "\n  Loading reports...\n" // Could be a spinner
+		return m.load.View(m.picker.MinSelected)
+	case StateSelectingMetrics:
+		return m.metrics.View(m.width, m.height)
 	case StateResults:
 		view := m.results.View()
-		footer := "\n  (Esc: Back • s: Save Report • q: Quit)"
-		
+		if m.results.ShowingHelp() || m.results.ShowingDrillDown() {
+			return view
+		}
+		keys := fmt.Sprintf("(Esc: Back • ↑/↓ Enter: Drill Down • b: Chart • d: Details • 1-9: Columns • f: Format [%s] • s: Save Report • y: Copy • ?: Help • q: Quit)", m.results.SaveFormatName())
+		footer := "\n  " + keys
 		if m.results.SaveMsg != "" {
 			color := "42" // Green
 			if strings.HasPrefix(m.results.SaveMsg, "Error") {
 				color = "196" // Red
 			}
 			msg := lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Bold(true).Render(m.results.SaveMsg)
-			footer = fmt.Sprintf("\n  %s\n  (Esc: Back • s: Save Report • q: Quit)", msg)
+			footer = "\n  " + msg + footer
 		}
-		
+		// Skipped files stay listed above any save message
+		if warn := m.load.failureSummary(); warn != "" {
+			footer = "\n  " + loadErrStyle.Render(warn) + footer
+		}
+
 		return view + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(footer)
 	}
 	return ""
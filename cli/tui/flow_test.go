@@ -0,0 +1,28 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFlowFooterKeepsFailuresWithSaveMessage(t *testing.T) {
+	m := CompareFlowModel{
+		state:   StateResults,
+		results: NewResultsModel(sameNameReports(10, 20)),
+		load: loadState{
+			paths: []string{"/tmp/a.json", "/tmp/broken.json", "/tmp/b.json"},
+			errs:  []error{nil, errors.New("unexpected end of JSON input"), nil},
+		},
+	}
+	for _, msg := range []string{"", "Saved to report.md", "Error: at least two products must stay visible"} {
+		m.results.SaveMsg = msg
+		view := m.View()
+		if !strings.Contains(view, "Skipped: broken.json") {
+			t.Errorf("with save message %q, footer lost the skipped files", msg)
+		}
+		if msg != "" && !strings.Contains(view, msg) {
+			t.Errorf("footer doesn't show save message %q", msg)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pickerKeyMap lists the file picker's shortcuts. List navigation (↑/↓, paging)
+// is handled by bubbles/list itself; Move is only here for the help overlay.
+type pickerKeyMap struct {
+	Move, Open, Toggle, All, Parent, Home, Root, WorkDir, Sort, Time, Recursive, Extensionless, Compare, Help, Quit key.Binding
+}
+
+var pickerKeys = pickerKeyMap{
+	Move:          key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑/↓", "move")),
+	Open:          key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "open folder / select file")),
+	Toggle:        key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "select / deselect file")),
+	All:           key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "select / deselect every file in this folder")),
+	Parent:        key.NewBinding(key.WithKeys("left", "backspace"), key.WithHelp("←/backspace", "parent folder")),
+	Home:          key.NewBinding(key.WithKeys("~"), key.WithHelp("~", "home folder")),
+	Root:          key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filesystem root")),
+	WorkDir:       key.NewBinding(key.WithKeys("."), key.WithHelp(".", "folder uxbench was started in")),
+	Sort:          key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "cycle sort order")),
+	Time:          key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "toggle age / modification time")),
+	Recursive:     key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "toggle recursive listing")),
+	Extensionless: key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "also show extensionless JSON files")),
+	Compare:       key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "compare selected files")),
+	Help:          key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "toggle help")),
+	Quit:          key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+}
+
+func (k pickerKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Toggle, k.Compare, k.Help, k.Quit}
+}
+
+func (k pickerKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Move, k.Open, k.Toggle, k.All, k.Parent},
+		{k.Home, k.Root, k.WorkDir},
+		{k.Sort, k.Time, k.Recursive, k.Extensionless, k.Compare, k.Help, k.Quit},
+	}
+}
+
+// resultsKeyMap lists the results screen's shortcuts.
+type resultsKeyMap struct {
+	Up, Down, PageUp, PageDown, ScrollLeft, ScrollRight, Drill, Explain, Legend, Chart, Details, PrevMetric, NextMetric, Column, AllColumns, Save, Format, Copy, QuickCSV, Back, Help, Quit key.Binding
+}
+
+var resultsKeys = resultsKeyMap{
+	Up:          key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "previous metric row")),
+	Down:        key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "next metric row")),
+	PageUp:      key.NewBinding(key.WithKeys("pgup"), key.WithHelp("pgup", "scroll the table up a page")),
+	PageDown:    key.NewBinding(key.WithKeys("pgdown"), key.WithHelp("pgdn", "scroll the table down a page")),
+	ScrollLeft:  key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←/h", "table: scroll product columns left")),
+	ScrollRight: key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→/l", "table: scroll product columns right")),
+	Drill:       key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "drill down (click rows)")),
+	Explain:     key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "explain the metric row")),
+	Legend:      key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "toggle the legend")),
+	Chart:       key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "toggle bar chart")),
+	Details:     key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "toggle per-product details")),
+	PrevMetric:  key.NewBinding(key.WithKeys("left", "h", "up", "k"), key.WithHelp("←/h", "chart: previous metric")),
+	NextMetric:  key.NewBinding(key.WithKeys("right", "l", "down", "j"), key.WithHelp("→/l", "chart: next metric")),
+	Column:      key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"), key.WithHelp("1-9", "hide / show a product column")),
+	AllColumns:  key.NewBinding(key.WithKeys("0"), key.WithHelp("0", "show all product columns")),
+	Save:        key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "save report…")),
+	Format:      key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "cycle save format")),
+	Copy:        key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "copy markdown table")),
+	QuickCSV:    key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "save comparison_report.csv")),
+	Back:        key.NewBinding(key.WithKeys("esc", "backspace"), key.WithHelp("esc", "back to the file picker")),
+	Help:        key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "toggle help")),
+	Quit:        key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+}
+
+func (k resultsKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Chart, k.Details, k.Save, k.Help, k.Quit}
+}
+
+func (k resultsKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.PageUp, k.PageDown, k.ScrollLeft, k.ScrollRight},
+		{k.Drill, k.Explain, k.Legend},
+		{k.Chart, k.PrevMetric, k.NextMetric, k.Details, k.Column, k.AllColumns},
+		{k.Save, k.Format, k.Copy, k.QuickCSV},
+		{k.Back, k.Help, k.Quit},
+	}
+}
+
+var helpBoxStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("62")).Padding(1, 2).Margin(1, 2)
+
+// helpOverlay renders every binding in km as a full-screen help panel.
+func helpOverlay(title string, km help.KeyMap) string {
+	h := help.New()
+	h.ShowAll = true
+	h.FullSeparator = "    "
+	body := resultsTitleStyle.Render(title) + "\n\n" + h.View(km) + "\n\n" +
+		categoryStyle.Render("Press ? or esc to close")
+	return helpBoxStyle.Render(body)
+}
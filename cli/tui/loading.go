@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"uxbench/cli/loader"
+	"uxbench/schema"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var loadErrStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+
+// loadState tracks an in-flight concurrent load of the picker's selection.
+type loadState struct {
+	gen     int // bumped per load; results from a canceled load are ignored
+	paths   []string
+	reports []*schema.BenchmarkReport // indexed like paths; nil until loaded or on error
+	errs    []error
+	done    int
+	spinner spinner.Model
+}
+
+// fileLoadedMsg reports the outcome of loading one selected file.
+type fileLoadedMsg struct {
+	gen    int
+	index  int
+	report *schema.BenchmarkReport
+	err    error
+}
+
+// startLoad begins loading paths concurrently: one command per file, so progress
+// can be reported as each one finishes.
+func startLoad(gen int, paths []string) (loadState, tea.Cmd) {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+
+	ls := loadState{
+		gen:     gen,
+		paths:   append([]string(nil), paths...),
+		reports: make([]*schema.BenchmarkReport, len(paths)),
+		errs:    make([]error, len(paths)),
+		spinner: s,
+	}
+
+	cmds := []tea.Cmd{ls.spinner.Tick}
+	for i, p := range ls.paths {
+		i, p := i, p
+		cmds = append(cmds, func() tea.Msg {
+			r, err := loader.LoadMetricsOnly(p)
+			return fileLoadedMsg{gen: gen, index: i, report: r, err: err}
+		})
+	}
+	return ls, tea.Batch(cmds...)
+}
+
+// record stores a finished file and reports whether every file is done.
+func (ls *loadState) record(msg fileLoadedMsg) bool {
+	ls.reports[msg.index] = msg.report
+	ls.errs[msg.index] = msg.err
+	ls.done++
+	return ls.done == len(ls.paths)
+}
+
+// loaded returns the successfully loaded reports in selection order.
+func (ls loadState) loaded() []*schema.BenchmarkReport {
+	var out []*schema.BenchmarkReport
+	for _, r := range ls.reports {
+		if r != nil {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func (ls loadState) failed() int {
+	n := 0
+	for _, err := range ls.errs {
+		if err != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func (ls loadState) finished() bool {
+	return ls.done == len(ls.paths)
+}
+
+func (ls loadState) View(minReports int) string {
+	var s strings.Builder
+	if ls.finished() {
+		s.WriteString(fmt.Sprintf("\n  Loaded %d/%d reports.\n", len(ls.loaded()), len(ls.paths)))
+	} else {
+		s.WriteString(fmt.Sprintf("\n  %s Loading reports... %d/%d loaded\n", ls.spinner.View(), ls.done, len(ls.paths)))
+	}
+
+	for i, err := range ls.errs {
+		if err != nil {
+			s.WriteString(loadErrStyle.Render(fmt.Sprintf("  ✗ %s: %v", filepath.Base(ls.paths[i]), err)) + "\n")
+		}
+	}
+
+	help := "(Esc: Cancel)"
+	if ls.finished() && len(ls.loaded()) < minReports {
+		help = fmt.Sprintf("(Only %d of %d reports loaded; need at least %d • Esc: Back)", len(ls.loaded()), len(ls.paths), minReports)
+	}
+	s.WriteString("\n  " + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(help))
+	return s.String()
+}
+
+// failureSummary names the files that couldn't be loaded, or "" if all loaded.
+func (ls loadState) failureSummary() string {
+	var names []string
+	for i, err := range ls.errs {
+		if err != nil {
+			names = append(names, fmt.Sprintf("%s (%v)", filepath.Base(ls.paths[i]), err))
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return "Skipped: " + strings.Join(names, ", ")
+}
@@ -20,7 +20,21 @@ var (
 	helpStyle         = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
 )
 
-type item string
+// MenuChoice identifies the action picked from the root menu.
+type MenuChoice int
+
+const (
+	MenuNone MenuChoice = iota // user quit without selecting
+	MenuCompare
+	MenuSummarize
+	MenuExport
+	MenuQuit
+)
+
+type item struct {
+	title  string
+	choice MenuChoice
+}
 
 func (i item) FilterValue() string { return "" }
 
@@ -35,7 +49,7 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 		return
 	}
 
-	str := fmt.Sprintf("%d. %s", index+1, i)
+	str := fmt.Sprintf("%d. %s", index+1, i.title)
 
 	fn := itemStyle.Render
 	if index == m.Index() {
@@ -49,17 +63,19 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 
 type MenuModel struct {
 	list     list.Model
-	Selected string
+	Selected MenuChoice
 	Quitting bool
 }
 
 func NewMenuModel() MenuModel {
 	items := []list.Item{
-		item("Compare Recordings"),
-		item("Exit"),
+		item{title: "Compare Recordings", choice: MenuCompare},
+		item{title: "Summarize a Recording", choice: MenuSummarize},
+		item{title: "Export Comparison (Markdown + CSV)", choice: MenuExport},
+		item{title: "Quit", choice: MenuQuit},
 	}
 
-	const defaultWidth = 20
+	const defaultWidth = 40
 
 	l := list.New(items, itemDelegate{}, defaultWidth, listHeight)
 	l.Title = "UX Bench - Select Mode"
@@ -80,14 +96,16 @@ func (m MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "ctrl+c":
+		case "ctrl+c", "q", "esc":
+			m.Selected = MenuNone
 			m.Quitting = true
 			return m, tea.Quit
 		case "enter":
 			i, ok := m.list.SelectedItem().(item)
 			if ok {
-				m.Selected = string(i)
+				m.Selected = i.choice
 			}
+			m.Quitting = true
 			return m, tea.Quit
 		}
 	}
@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"uxbench/cli/format"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// metricSelectKeyMap lists the metric checklist's shortcuts.
+type metricSelectKeyMap struct {
+	Up, Down, Toggle, All, None, Defaults, Confirm key.Binding
+}
+
+var metricSelectKeys = metricSelectKeyMap{
+	Up:       key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "previous metric")),
+	Down:     key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "next metric")),
+	Toggle:   key.NewBinding(key.WithKeys(" ", "x"), key.WithHelp("space", "check / uncheck")),
+	All:      key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "check all")),
+	None:     key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "uncheck all")),
+	Defaults: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "reset to the default set")),
+	Confirm:  key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "compare")),
+}
+
+// metricSelectModel is the checklist of registry metrics shown between loading
+// and results. It lists every metric, detail-only ones included, grouped by
+// category.
+type metricSelectModel struct {
+	metrics []format.MetricDef // display order: grouped by category
+	checked map[string]bool    // by Key
+	cursor  int
+	msg     string // shown under the list, e.g. when nothing is checked
+}
+
+// newMetricSelect pre-checks preset when given (a --metrics selection),
+// otherwise the metrics the default layout shows.
+func newMetricSelect(preset []format.MetricDef) metricSelectModel {
+	var m metricSelectModel
+	for _, g := range format.GroupedMetrics(true) {
+		m.metrics = append(m.metrics, g.Metrics...)
+	}
+	m.checked = map[string]bool{}
+	if len(preset) == 0 {
+		m = m.withDefaults()
+	}
+	for _, def := range preset {
+		m.checked[def.Key] = true
+	}
+	return m
+}
+
+func (m metricSelectModel) withDefaults() metricSelectModel {
+	m.checked = map[string]bool{}
+	for _, def := range m.metrics {
+		m.checked[def.Key] = !def.DetailOnly
+	}
+	return m
+}
+
+// isDefault reports whether the checked set is exactly the default layout's,
+// in which case the results keep their category headers.
+func (m metricSelectModel) isDefault() bool {
+	for _, def := range m.metrics {
+		if m.checked[def.Key] == def.DetailOnly {
+			return false
+		}
+	}
+	return true
+}
+
+// selected returns the checked metrics in display order.
+func (m metricSelectModel) selected() []format.MetricDef {
+	var out []format.MetricDef
+	for _, def := range m.metrics {
+		if m.checked[def.Key] {
+			out = append(out, def)
+		}
+	}
+	return out
+}
+
+// update handles a key and reports whether the selection was confirmed.
+func (m metricSelectModel) update(msg tea.KeyMsg) (metricSelectModel, bool) {
+	m.msg = ""
+	switch {
+	case key.Matches(msg, metricSelectKeys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(msg, metricSelectKeys.Down):
+		if m.cursor < len(m.metrics)-1 {
+			m.cursor++
+		}
+	case key.Matches(msg, metricSelectKeys.Toggle):
+		k := m.metrics[m.cursor].Key
+		m.checked[k] = !m.checked[k]
+	case key.Matches(msg, metricSelectKeys.All):
+		for _, def := range m.metrics {
+			m.checked[def.Key] = true
+		}
+	case key.Matches(msg, metricSelectKeys.None):
+		m.checked = map[string]bool{}
+	case key.Matches(msg, metricSelectKeys.Defaults):
+		m = m.withDefaults()
+	case key.Matches(msg, metricSelectKeys.Confirm):
+		if len(m.selected()) == 0 {
+			m.msg = "Check at least one metric"
+			return m, false
+		}
+		return m, true
+	}
+	return m, false
+}
+
+// View renders the checklist, scrolled to keep the cursor within height lines.
+func (m metricSelectModel) View(width, height int) string {
+	var lines []string
+	cursorLine := 0
+	category := ""
+	for i, def := range m.metrics {
+		if def.Category != category {
+			category = def.Category
+			lines = append(lines, categoryStyle.Render("  "+category))
+		}
+		box := "[ ]"
+		style := fileStyle
+		if m.checked[def.Key] {
+			box = "[x]"
+			style = checkedItemStyle
+		}
+		prefix := "    "
+		if i == m.cursor {
+			prefix = "  > "
+			style = pickerSelectedItemStyle
+			cursorLine = len(lines)
+		}
+		line := prefix + style.Render(box+" "+def.Label)
+		if def.DetailOnly {
+			line += categoryStyle.Render("  (detail)")
+		}
+		lines = append(lines, line)
+	}
+
+	// Title, count, description and footer take 6 lines
+	if avail := height - 6; avail > 0 && len(lines) > avail {
+		start := cursorLine - avail/2
+		if start < 0 {
+			start = 0
+		}
+		if start > len(lines)-avail {
+			start = len(lines) - avail
+		}
+		lines = lines[start : start+avail]
+	}
+
+	var b strings.Builder
+	b.WriteString("\n" + resultsTitleStyle.Render("Choose Metrics"))
+	b.WriteString(metaStyle.Render(fmt.Sprintf("  %d of %d checked", len(m.selected()), len(m.metrics))) + "\n\n")
+	b.WriteString(strings.Join(lines, "\n") + "\n")
+	if desc := m.metrics[m.cursor].Description; desc != "" {
+		b.WriteString("  " + metaStyle.Render(runewidth.Truncate(desc, max(width-4, 20), "…")) + "\n")
+	}
+	if m.msg != "" {
+		b.WriteString("  " + loadErrStyle.Render(m.msg) + "\n")
+	}
+	keys := "(↑/↓: Move • Space: Toggle • a: All • n: None • r: Reset • Enter: Compare • Esc: Back • q: Quit)"
+	b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("  " + keys))
+	return b.String()
+}
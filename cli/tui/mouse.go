@@ -0,0 +1,22 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// frameLine converts a mouse row on screen into a line index of view.
+// Mouse-enabled programs run in the alternate screen, where the renderer shows
+// a view taller than the terminal bottom-aligned (its top lines are dropped).
+// Terminals without mouse reporting simply never send mouse messages.
+func frameLine(y int, view string, height int) int {
+	if h := lipgloss.Height(view); height > 0 && h > height {
+		return y + h - height
+	}
+	return y
+}
+
+// isClick reports whether msg is a left-button press.
+func isClick(msg tea.MouseMsg) bool {
+	return msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft
+}
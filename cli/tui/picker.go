@@ -6,12 +6,16 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"uxbench/cli/loader"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/dustin/go-humanize"
+	"github.com/mattn/go-runewidth"
 )
 
 var (
@@ -43,7 +47,14 @@ type fileItem struct {
 
 func (i fileItem) FilterValue() string { return i.name }
 
-type fileDelegate struct{}
+// fileDelegate renders one picker row. The time column shows each entry's age
+// ("2 hours ago") unless absoluteTime is set (t toggles it).
+type fileDelegate struct {
+	absoluteTime bool
+}
+
+// timeStyle fits the longest humanize.Time phrase ("a long while ago").
+var ageStyle = permissionStyle.Width(16)
 
 func (d fileDelegate) Height() int                             { return 1 }
 func (d fileDelegate) Spacing() int                            { return 0 }
@@ -54,7 +65,7 @@ func (d fileDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 		return
 	}
 
-	// Columns: Check | Mode | Size | ModTime | Name
+	// Columns: Check | Mode | Size | Age (or ModTime) | Name
 	var str string
 	var mode, size, modTime string
 	
@@ -63,11 +74,14 @@ func (d fileDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 	if i.name == ".." {
 		mode = "drwxr-xr-x"
 		size = "-"
-		modTime = "            "
+		modTime = ""
 	} else if i.info != nil {
 		mode = i.info.Mode().String()
 		size = humanize.Bytes(uint64(i.info.Size()))
-		modTime = i.info.ModTime().Format("Jan 02 15:04")
+		modTime = humanize.Time(i.info.ModTime())
+		if d.absoluteTime {
+			modTime = i.info.ModTime().Format("Jan 02 15:04")
+		}
 		
 		if i.isDir {
 			size = "-"
@@ -115,31 +129,86 @@ func (d fileDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 		checkRender,
 		permissionStyle.Render(mode),
 		sizeStyle.Render(size),
-		permissionStyle.Render(modTime),
+		ageStyle.Render(modTime),
 		nameStyle.Render(name),
 	)
 
 	fmt.Fprint(w, str)
 }
 
+// SortMode controls how picker entries are ordered within their group.
+type SortMode int
+
+const (
+	SortByName SortMode = iota
+	SortBySize
+	SortByModTime
+)
+
+func (s SortMode) String() string {
+	switch s {
+	case SortBySize:
+		return "size"
+	case SortByModTime:
+		return "modified"
+	default:
+		return "name"
+	}
+}
+
+// next cycles name -> size -> modified -> name.
+func (s SortMode) next() SortMode {
+	return (s + 1) % 3
+}
+
 type Model struct {
 	list       list.Model
 	currentDir string
+	workDir    string // where uxbench was started, for the jump back (.)
+	sortMode   SortMode
+
+	recursive     bool // flatten all .json files below currentDir
+	extensionless bool // also list files without an extension that look like JSON
+	walkTruncated bool // recursive walk hit walkMaxDepth/walkMaxFiles
 	
 	// Track selected files
 	SelectedPaths []string
+	MinSelected   int // files required before 'c' confirms
+	MaxSelected   int // 0 = unlimited
+	selectedBytes int64 // combined size of SelectedPaths, updated on toggle
+
+	notice string // transient staging-area message, cleared on the next key
+
+	showHelp     bool // full-screen key help (see keys.go)
+	height       int  // terminal height, to map mouse rows (see mouse.go)
+	absoluteTime bool // time column shows modification times instead of ages
 	
+	// Hover preview state (see preview.go)
+	previews   map[string]previewResult
+	previewSeq int
+
 	quitting   bool
 	done       bool
 }
 
 func NewModel() Model {
-	cwd, _ := os.Getwd()
+	return NewModelWithLimit(0)
+}
+
+// NewModelWithLimit creates a picker that refuses to select more than max files (0 = unlimited).
+func NewModelWithLimit(max int) Model {
+	workDir, _ := os.Getwd()
+	cwd := workDir
+	if PickerRoot != "" {
+		cwd = PickerRoot
+	} else if last := loadLastDir(); last != "" {
+		cwd = last
+	}
 	
 	// We need to initialize the list items with selection state if we reload folders,
 	// checking against SelectedPaths.
 	
-	l := list.New(getItems(cwd, nil), fileDelegate{}, 80, 20)
+	l := list.New(getItems(cwd, nil, SortByName, false), fileDelegate{}, 80, 20)
 	l.Title = "Select Files to Compare"
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(false)
@@ -148,12 +217,21 @@ func NewModel() Model {
 	return Model{
 		list:          l,
 		currentDir:    cwd,
+		workDir:       workDir,
 		SelectedPaths: []string{},
+		MinSelected:   2,
+		MaxSelected:   max,
+		previews:      make(map[string]previewResult),
 	}
 }
 
+// Confirmed reports whether the user finished picking with 'c' (rather than quitting).
+func (m Model) Confirmed() bool {
+	return m.done
+}
+
 // Helper to get items and mark them selected if they are in the list
-func getItems(dir string, selected []string) []list.Item {
+func getItems(dir string, selected []string, mode SortMode, extensionless bool) []list.Item {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return []list.Item{}
@@ -188,34 +266,268 @@ func getItems(dir string, selected []string) []list.Item {
 
 		if e.IsDir() {
 			dirs = append(dirs, item)
-		} else if strings.HasSuffix(e.Name(), ".json") {
+		} else if isListed(fullPath, extensionless) {
 			files = append(files, item)
 		}
 	}
-    
+
+	// ".." stays pinned at the top; everything else is sorted within its group.
+	sortFileItems(dirs[1:], mode)
+	sortFileItems(files, mode)
+
     items := make([]list.Item, 0, len(dirs)+len(files))
     for _, d := range dirs { items = append(items, d) }
     for _, f := range files { items = append(items, f) }
     return items
 }
 
+// isListed reports whether the picker shows the file at path: always for
+// .json files, and for extensionless JSON files when extensionless is on.
+func isListed(path string, extensionless bool) bool {
+	if strings.HasSuffix(path, ".json") {
+		return true
+	}
+	return extensionless && loader.IsReportFile(path)
+}
+
+// Limits for recursive mode so a huge tree can't stall the picker.
+const (
+	walkMaxDepth = 6
+	walkMaxFiles = 1000
+)
+
+// walkItems flattens every .json file under dir into a single list, named by relative path.
+// The second return value reports whether the walk was cut short by the depth or file limits.
+func walkItems(dir string, selected []string, mode SortMode, extensionless bool) ([]list.Item, bool) {
+	selectedMap := make(map[string]bool)
+	for _, p := range selected {
+		selectedMap[p] = true
+	}
+
+	var files []fileItem
+	truncated := false
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // skip unreadable entries
+		}
+		if path == dir {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		if d.IsDir() {
+			if strings.Count(rel, string(filepath.Separator))+1 >= walkMaxDepth {
+				truncated = true
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isListed(path, extensionless) {
+			return nil
+		}
+		if len(files) >= walkMaxFiles {
+			truncated = true
+			return filepath.SkipAll
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		files = append(files, fileItem{
+			name:       rel,
+			path:       path,
+			info:       info,
+			isSelected: selectedMap[path],
+		})
+		return nil
+	})
+
+	sortFileItems(files, mode)
+
+	items := make([]list.Item, 0, len(files)+1)
+	items = append(items, fileItem{name: "..", path: filepath.Dir(dir), isDir: true})
+	for _, f := range files {
+		items = append(items, f)
+	}
+	return items, truncated
+}
+
+// sortFileItems orders items by mode. Size and mod time sort largest/newest first.
+func sortFileItems(items []fileItem, mode SortMode) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		switch mode {
+		case SortBySize:
+			if a.info.Size() != b.info.Size() {
+				return a.info.Size() > b.info.Size()
+			}
+		case SortByModTime:
+			if !a.info.ModTime().Equal(b.info.ModTime()) {
+				return a.info.ModTime().After(b.info.ModTime())
+			}
+		}
+		return strings.ToLower(a.name) < strings.ToLower(b.name)
+	})
+}
+
+// jumpTo browses dir directly. Selections live in SelectedPaths, so they
+// survive the jump and reappear checked when their folder is shown again.
+func (m Model) jumpTo(dir string) (Model, tea.Cmd) {
+	m.currentDir = dir
+	cmd := m.reload()
+	m.list.ResetSelected()
+	return m, cmd
+}
+
+// breadcrumb shortens dir to fit width for the picker title: the home
+// directory becomes ~, and middle segments are elided ("~/…/2024/notion").
+func breadcrumb(dir string, width int) string {
+	sep := string(filepath.Separator)
+	if home, err := os.UserHomeDir(); err == nil && home != sep && (dir == home || strings.HasPrefix(dir, home+sep)) {
+		dir = "~" + strings.TrimPrefix(dir, home)
+	}
+	if runewidth.StringWidth(dir) <= width {
+		return dir
+	}
+
+	parts := strings.Split(dir, sep)
+	head := parts[0] // "~", or "" for an absolute path
+	if head != "" {
+		head += sep
+	}
+	crumb := parts[len(parts)-1]
+	for i := len(parts) - 2; i >= 1; i-- {
+		next := parts[i] + sep + crumb
+		if runewidth.StringWidth(head+"…"+sep+next) > width {
+			break
+		}
+		crumb = next
+	}
+	return head + "…" + sep + crumb
+}
+
+// setSize fits the list beside the preview pane, reserving space for header/footer.
+func (m *Model) setSize(width, height int) {
+	m.height = height
+	m.list.SetSize(width-previewWidth-4, height-4)
+}
+
+// pickerListTop is the number of lines the list's title bar takes above the first item.
+const pickerListTop = 2
+
+// pickerCheckWidth covers the cursor and "[ ]" columns at the start of each row.
+const pickerCheckWidth = 6
+
+// updateMouse moves the cursor to a clicked row, toggles a file when its
+// checkbox is clicked, and scrolls the list with the wheel.
+func (m Model) updateMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.list.CursorUp()
+		return m, nil
+	case tea.MouseButtonWheelDown:
+		m.list.CursorDown()
+		return m, nil
+	}
+	if !isClick(msg) || m.showHelp {
+		return m, nil
+	}
+
+	row := frameLine(msg.Y, m.View(), m.height) - lipgloss.Height(m.stagingView()) - pickerListTop
+	if row < 0 || row >= m.list.Paginator.PerPage {
+		return m, nil
+	}
+	idx := m.list.Paginator.Page*m.list.Paginator.PerPage + row
+	if idx >= len(m.list.VisibleItems()) {
+		return m, nil
+	}
+	m.list.Select(idx)
+
+	if i, ok := m.list.SelectedItem().(fileItem); ok && !i.isDir && msg.X < pickerCheckWidth {
+		return m.toggleSelection(i)
+	}
+	return m, nil
+}
+
+// reload re-reads the current directory, preserving selection state and sort order.
+func (m *Model) reload() tea.Cmd {
+	if m.recursive {
+		items, truncated := walkItems(m.currentDir, m.SelectedPaths, m.sortMode, m.extensionless)
+		m.walkTruncated = truncated
+		return m.list.SetItems(items)
+	}
+	m.walkTruncated = false
+	return m.list.SetItems(getItems(m.currentDir, m.SelectedPaths, m.sortMode, m.extensionless))
+}
+
 func (m Model) Init() tea.Cmd {
 	return nil
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case previewTickMsg:
+		if msg.seq != m.previewSeq {
+			return m, nil // cursor moved on; a newer tick is pending
+		}
+		if _, ok := m.previews[msg.path]; ok {
+			return m, nil
+		}
+		return m, loadPreview(msg.path)
+
+	case previewLoadedMsg:
+		m.previews[msg.path] = previewResult{header: msg.header, err: msg.err}
+		return m, nil
+	}
+
+	before := m.hoveredFile()
+	newModel, cmd := m.update(msg)
+	m = newModel.(Model)
+
+	// Debounce a preview load whenever the cursor lands on a different file
+	if after := m.hoveredFile(); after != before {
+		m.previewSeq++
+		if _, ok := m.previews[after]; after != "" && !ok {
+			cmd = tea.Batch(cmd, schedulePreview(m.previewSeq, after))
+		}
+	}
+	return m, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
+		m.notice = ""
+		if m.showHelp {
+			// The overlay swallows keys until it is closed
+			if key.Matches(msg, pickerKeys.Help) || msg.String() == "esc" {
+				m.showHelp = false
+			} else if msg.String() == "ctrl+c" {
+				m.quitting = true
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, pickerKeys.Help):
+			m.showHelp = true
+			return m, nil
+
+		case key.Matches(msg, pickerKeys.Quit):
 			m.quitting = true
+			saveLastDir(m.currentDir)
 			return m, tea.Quit
 		
-		case "enter":
+		case key.Matches(msg, pickerKeys.Open):
 			i, ok := m.list.SelectedItem().(fileItem)
 			if ok && i.isDir {
 				m.currentDir = i.path
-				cmd := m.list.SetItems(getItems(m.currentDir, m.SelectedPaths))
+				cmd := m.reload()
 				m.list.ResetSelected()
 				return m, cmd
 			}
@@ -226,28 +538,66 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
-		case " ":
+		case key.Matches(msg, pickerKeys.Toggle):
 			i, ok := m.list.SelectedItem().(fileItem)
 			if ok && !i.isDir {
 				return m.toggleSelection(i)
 			}
+
+		case key.Matches(msg, pickerKeys.All):
+			return m.toggleAll()
 		
-		case "left", "backspace": 
+		case key.Matches(msg, pickerKeys.Home):
+			if home, err := os.UserHomeDir(); err == nil {
+				return m.jumpTo(home)
+			}
+			return m, nil
+
+		case key.Matches(msg, pickerKeys.Root):
+			return m.jumpTo(filepath.VolumeName(m.currentDir) + string(filepath.Separator))
+
+		case key.Matches(msg, pickerKeys.WorkDir):
+			return m.jumpTo(m.workDir)
+
+		case key.Matches(msg, pickerKeys.Parent):
 			parent := filepath.Dir(m.currentDir)
 			m.currentDir = parent
-			cmd := m.list.SetItems(getItems(m.currentDir, m.SelectedPaths))
+			cmd := m.reload()
 			m.list.ResetSelected()
 			return m, cmd
 			
-		case "c":
-			if len(m.SelectedPaths) >= 2 {
+		case key.Matches(msg, pickerKeys.Sort):
+			m.sortMode = m.sortMode.next()
+			return m, m.reload()
+
+		case key.Matches(msg, pickerKeys.Time):
+			m.absoluteTime = !m.absoluteTime
+			m.list.SetDelegate(fileDelegate{absoluteTime: m.absoluteTime})
+			return m, nil
+
+		case key.Matches(msg, pickerKeys.Recursive):
+			m.recursive = !m.recursive
+			cmd := m.reload()
+			m.list.ResetSelected()
+			return m, cmd
+
+		case key.Matches(msg, pickerKeys.Extensionless):
+			m.extensionless = !m.extensionless
+			return m, m.reload()
+
+		case key.Matches(msg, pickerKeys.Compare):
+			if len(m.SelectedPaths) >= m.MinSelected {
 				m.done = true
+				saveLastDir(m.currentDir)
 				return m, tea.Quit
 			}
 		}
 
+	case tea.MouseMsg:
+		return m.updateMouse(msg)
+
 	case tea.WindowSizeMsg:
-		m.list.SetSize(msg.Width, msg.Height-4) // Reserve space for header/footer
+		m.setSize(msg.Width, msg.Height)
 	}
 
 	var cmd tea.Cmd
@@ -269,19 +619,114 @@ func (m Model) toggleSelection(i fileItem) (Model, tea.Cmd) {
 		// Deselect
 		m.SelectedPaths = append(m.SelectedPaths[:idx], m.SelectedPaths[idx+1:]...)
 	} else {
-		// Select (no limit, or limit > 2 for multi-compare)
+		if m.MaxSelected > 0 && len(m.SelectedPaths) >= m.MaxSelected {
+			m.notice = fmt.Sprintf("Selection limit reached (max %d). Deselect a file first.", m.MaxSelected)
+			return m, nil
+		}
 		m.SelectedPaths = append(m.SelectedPaths, i.path)
 	}
 	
+	m.selectedBytes = totalSize(m.SelectedPaths)
+
 	// Refresh list to update checkmarks
-	cmd := m.list.SetItems(getItems(m.currentDir, m.SelectedPaths))
+	cmd := m.reload()
 	return m, cmd
 }
 
+// toggleAll selects every file listed in the current folder (all of them in
+// recursive mode), or deselects them all when they already are. Selections
+// made in other folders are left alone.
+func (m Model) toggleAll() (Model, tea.Cmd) {
+	var listed []string
+	for _, it := range m.list.Items() {
+		if i, ok := it.(fileItem); ok && !i.isDir {
+			listed = append(listed, i.path)
+		}
+	}
+	if len(listed) == 0 {
+		return m, nil
+	}
+
+	selected := make(map[string]bool)
+	for _, p := range m.SelectedPaths {
+		selected[p] = true
+	}
+	var missing []string
+	for _, p := range listed {
+		if !selected[p] {
+			missing = append(missing, p)
+		}
+	}
+
+	if len(missing) == 0 {
+		inFolder := make(map[string]bool)
+		for _, p := range listed {
+			inFolder[p] = true
+		}
+		kept := []string{}
+		for _, p := range m.SelectedPaths {
+			if !inFolder[p] {
+				kept = append(kept, p)
+			}
+		}
+		m.SelectedPaths = kept
+	} else {
+		if m.MaxSelected > 0 && len(m.SelectedPaths)+len(missing) > m.MaxSelected {
+			room := max(m.MaxSelected-len(m.SelectedPaths), 0)
+			m.notice = fmt.Sprintf("Selection limit reached (max %d); %d file(s) in this folder left unselected.", m.MaxSelected, len(missing)-room)
+			missing = missing[:room]
+		}
+		m.SelectedPaths = append(m.SelectedPaths, missing...)
+	}
+
+	m.selectedBytes = totalSize(m.SelectedPaths)
+	return m, m.reload()
+}
+
+// largeSelectionBytes is the combined size above which the staging area warns
+// that loading will be slow.
+const largeSelectionBytes = 100 << 20
+
+// totalSize sums the sizes of paths, skipping files that can't be stat'ed.
+func totalSize(paths []string) int64 {
+	var total int64
+	for _, p := range paths {
+		if info, err := os.Stat(p); err == nil {
+			total += info.Size()
+		}
+	}
+	return total
+}
+
 func (m Model) View() string {
 	if m.quitting { return "" }
+	if m.showHelp {
+		return helpOverlay("File Picker Keys", pickerKeys)
+	}
+	
+	header := m.stagingView()
+	
+	// Leave room for the sort and recursive tags beside the path
+	m.list.Title = fmt.Sprintf("Browse: %s  [sort: %s]", breadcrumb(m.currentDir, max(m.list.Width()-40, 20)), m.sortMode)
+	if m.extensionless {
+		m.list.Title += "  [+no ext]"
+	}
+	if m.recursive {
+		m.list.Title += "  [recursive]"
+		if m.walkTruncated {
+			m.list.Title += fmt.Sprintf("  (showing first %d files / depth %d)", walkMaxFiles, walkMaxDepth)
+		}
+	}
 	
-	// Render Staging Area
+	help := "\n  (Space/Enter: Select • a: All in Folder • c: Compare • s: Sort • t: Age/Time • r: Recursive • e: No-ext files • Backspace: Up • ~ / .: Jump • ?: Help)"
+
+	body := lipgloss.JoinHorizontal(lipgloss.Top, m.list.View(), "  ", m.renderPreview())
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, body, help)
+}
+
+// stagingView renders the selected-files box above the list.
+func (m Model) stagingView() string {
 	var staging strings.Builder
 	staging.WriteString("Comparison Staging:\n")
 	if len(m.SelectedPaths) == 0 {
@@ -293,17 +738,22 @@ func (m Model) View() string {
 		}
 	}
 	
-	if len(m.SelectedPaths) >= 2 {
-		staging.WriteString("\n" + lipgloss.NewStyle().Background(lipgloss.Color("62")).Foreground(lipgloss.Color("255")).Bold(true).Padding(0,1).Render(" Press 'c' to Compare! "))
-	} else {
-		staging.WriteString(fmt.Sprintf("\n  %d files selected (pick at least 2)", len(m.SelectedPaths)))
+	// Live count and combined size, so a slow load doesn't come as a surprise
+	status := fmt.Sprintf("\n  %d files selected • %s", len(m.SelectedPaths), humanize.Bytes(uint64(m.selectedBytes)))
+	if len(m.SelectedPaths) < m.MinSelected {
+		status += fmt.Sprintf(" (pick at least %d)", m.MinSelected)
+	}
+	staging.WriteString(status)
+	if m.selectedBytes > largeSelectionBytes {
+		staging.WriteString("\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true).Render("  Large selection: loading may be slow"))
+	}
+
+	if len(m.SelectedPaths) >= m.MinSelected {
+		staging.WriteString("\n\n" + lipgloss.NewStyle().Background(lipgloss.Color("62")).Foreground(lipgloss.Color("255")).Bold(true).Padding(0,1).Render(" Press 'c' to Continue! "))
+	}
+	if m.notice != "" {
+		staging.WriteString("\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true).Render("  "+m.notice))
 	}
-	
-	header := stagingStyle.Render(staging.String())
-	
-	m.list.Title = fmt.Sprintf("Browse: %s", m.currentDir)
-	
-	help := "\n  (Space/Enter: Select • c: Compare • Backspace: Up)"
 
-	return lipgloss.JoinVertical(lipgloss.Left, header, m.list.View(), help)
+	return stagingStyle.Render(staging.String())
 }
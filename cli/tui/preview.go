@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"strings"
+	"time"
+	"uxbench/cli/format"
+	"uxbench/cli/loader"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// previewDelay debounces preview loading so fast scrolling doesn't load every file passed over.
+const previewDelay = 150 * time.Millisecond
+
+const previewWidth = 36
+
+var previewStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("240")).
+	Padding(0, 1).
+	Width(previewWidth)
+
+// previewTickMsg fires after the debounce delay; it is ignored if the cursor has moved since.
+type previewTickMsg struct {
+	seq  int
+	path string
+}
+
+type previewLoadedMsg struct {
+	path   string
+	header *loader.ReportHeader
+	err    error
+}
+
+type previewResult struct {
+	header *loader.ReportHeader
+	err    error
+}
+
+func schedulePreview(seq int, path string) tea.Cmd {
+	return tea.Tick(previewDelay, func(time.Time) tea.Msg {
+		return previewTickMsg{seq: seq, path: path}
+	})
+}
+
+func loadPreview(path string) tea.Cmd {
+	return func() tea.Msg {
+		h, err := loader.LoadHeader(path)
+		return previewLoadedMsg{path: path, header: h, err: err}
+	}
+}
+
+// hoveredFile returns the path of the file under the cursor, or "" for directories.
+func (m Model) hoveredFile() string {
+	i, ok := m.list.SelectedItem().(fileItem)
+	if !ok || i.isDir {
+		return ""
+	}
+	return i.path
+}
+
+// qualityStyle colors a quality score: green when trustworthy, amber when
+// flagged as low, red when barely usable.
+func qualityStyle(score float64) lipgloss.Style {
+	switch {
+	case score >= format.LowQuality:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	case score >= 0.4:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	default:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	}
+}
+
+func (m Model) renderPreview() string {
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	path := m.hoveredFile()
+	if path == "" {
+		return previewStyle.Render(dim.Render("Hover a .json file\nto preview it"))
+	}
+
+	res, ok := m.previews[path]
+	if !ok {
+		return previewStyle.Render(dim.Render("Loading..."))
+	}
+	if res.err != nil {
+		return previewStyle.Render(lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("parse error"))
+	}
+
+	md := res.header.Metadata
+	var s strings.Builder
+	s.WriteString(headerStyle.Render(md.Product) + "\n")
+	s.WriteString(md.Task + "\n\n")
+	if !md.Timestamp.IsZero() {
+		s.WriteString(dim.Render("Recorded ") + md.Timestamp.Format("Jan 02 2006 15:04") + "\n")
+	}
+	s.WriteString(dim.Render("Composite ") + format.Fixed(res.header.CompositeScore) + "\n")
+	s.WriteString(dim.Render("Quality   ") + qualityStyle(res.header.Quality).Render(format.QualityPercent(res.header.Quality)))
+	for _, reason := range res.header.QualityReasons {
+		s.WriteString("\n" + dim.Render("  "+reason))
+	}
+	return previewStyle.Render(s.String())
+}
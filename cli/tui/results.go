@@ -3,59 +3,203 @@ package tui
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"uxbench/cli/format"
 	"uxbench/schema"
 
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 var (
 	resultsTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FAFAFA")).Background(lipgloss.Color("#7D56F4")).Padding(0, 1)
 	headerStyle       = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
 	winnerStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true) // Green
+	tieStyle          = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true) // Amber
 	// We use a base cell style with some right padding for separation
 	cellStyle         = lipgloss.NewStyle().PaddingRight(4)
+	sparkStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("99"))
+	categoryStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true)
+	metaStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	cursorStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Bold(true)
+	hiddenColumnStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Strikethrough(true)
 )
 
 type ResultsModel struct {
 	reports  []*schema.BenchmarkReport
+	opts     format.Options // metric selection, shared with saves and copies
 	quitting bool
 	Saved    bool // Track if saved
 	SaveMsg  string
+
+	// Save prompt (see save.go)
+	saveStage  saveStage
+	saveInput  textinput.Model
+	saveFormat int // index into format.Exporters
+
+	view        resultsView
+	chartMetric int  // index into chartDefs (see chart.go)
+	showHelp    bool // full-screen key help (see keys.go)
+
+	sortKey      string       // metric the products are sorted by (clicked row), "" = as loaded
+	sortReversed bool         // clicked twice: worst first instead of best first
+	hideLegend   bool         // legend under the table toggled off
+	columns      []int        // load position of each report, parallel to reports; nil = as loaded (see columns.go)
+	hidden       map[int]bool // product columns hidden with 1-9, by load position
+	height       int          // terminal height, to map mouse rows (see mouse.go)
+	width        int
+	colOffset    int             // first product column shown when the table is wider than the terminal (see scroll.go)
+	offset       int             // first body line shown when the table is taller than the terminal
+
+	cursor     int            // metric row under the table cursor, indexing Grid.Rows
+	drill      viewport.Model // scrollable drill-down panel (see drill.go)
+	drillTitle string         // heading of the open panel: click details or a metric explanation
+}
+
+// ShowingHelp reports whether the key help overlay is open; it owns every key until closed.
+func (m ResultsModel) ShowingHelp() bool {
+	return m.showHelp
+}
+
+// ShowingDrillDown reports whether the drill-down panel is open; esc closes it
+// rather than leaving the results, and the mouse wheel scrolls it.
+func (m ResultsModel) ShowingDrillDown() bool {
+	return m.view == viewDrill
+}
+
+// resultsView selects what the results screen shows.
+type resultsView int
+
+const (
+	viewTable   resultsView = iota
+	viewChart               // bar chart of one metric
+	viewDetails             // per-product diagnostics (see details.go)
+	viewDrill               // drill-down of the cursor row (see drill.go)
+)
+
+// toggleView switches to v, or back to the table if v is already showing.
+func (m ResultsModel) toggleView(v resultsView) ResultsModel {
+	if m.view == v {
+		m.view = viewTable
+	} else {
+		m.view = v
+	}
+	return m
 }
 
 func NewResultsModel(reports []*schema.BenchmarkReport) ResultsModel {
-	return ResultsModel{reports: reports}
+	return NewResultsModelWithOptions(reports, format.Options{})
+}
+
+// NewResultsModelWithOptions is NewResultsModel limited to opts.Metrics when set.
+func NewResultsModelWithOptions(reports []*schema.BenchmarkReport, opts format.Options) ResultsModel {
+	return ResultsModel{reports: reports, opts: opts}
 }
 
 func (m ResultsModel) Init() tea.Cmd { return nil }
 
 func (m ResultsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		return m.setSize(msg.Width, msg.Height), nil
+	}
+	if m.Prompting() {
+		return m.updateSave(msg)
+	}
+
+	if m.view == viewDrill && !m.showHelp {
+		return m.updateDrill(msg)
+	}
+
 	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		if isClick(msg) {
+			return m.clickAt(frameLine(msg.Y, m.View(), m.height)), nil
+		}
+		return m, nil
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c", "esc":
+		if m.showHelp {
+			if key.Matches(msg, resultsKeys.Help) || msg.String() == "esc" {
+				m.showHelp = false
+			} else if msg.String() == "ctrl+c" {
+				m.quitting = true
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, resultsKeys.Help):
+			m.showHelp = true
+			return m, nil
+		case key.Matches(msg, resultsKeys.Quit), key.Matches(msg, resultsKeys.Back):
 			m.quitting = true
 			return m, tea.Quit
-		case "s":
-			if !m.Saved {
-				// Generate and Save Markdown
-				content := format.GenerateMarkdownTable(m.reports)
-				filename := "comparison_report.md"
-				err := os.WriteFile(filename, []byte(content), 0644)
-				if err != nil {
-					m.SaveMsg = fmt.Sprintf("Error saving: %v", err)
-				} else {
-					m.Saved = true
-					m.SaveMsg = fmt.Sprintf("Saved to %s!", filename)
-				}
+		case m.view == viewTable && key.Matches(msg, resultsKeys.Up):
+			return m.moveCursor(-1), nil
+		case m.view == viewTable && key.Matches(msg, resultsKeys.Down):
+			return m.moveCursor(1), nil
+		case m.view == viewTable && key.Matches(msg, resultsKeys.Drill):
+			return m.openDrill(), nil
+		case m.view == viewTable && key.Matches(msg, resultsKeys.Explain):
+			return m.openExplain(), nil
+		case m.view == viewTable && key.Matches(msg, resultsKeys.ScrollLeft):
+			return m.scrollColumns(-1), nil
+		case m.view == viewTable && key.Matches(msg, resultsKeys.ScrollRight):
+			return m.scrollColumns(1), nil
+		case m.view == viewTable && key.Matches(msg, resultsKeys.PageUp):
+			return m.scrollPage(-1), nil
+		case m.view == viewTable && key.Matches(msg, resultsKeys.PageDown):
+			return m.scrollPage(1), nil
+		case m.view == viewTable && key.Matches(msg, resultsKeys.Legend):
+			m.hideLegend = !m.hideLegend
+			return m, nil
+		case key.Matches(msg, resultsKeys.Chart):
+			return m.toggleView(viewChart), nil
+		case key.Matches(msg, resultsKeys.Details):
+			return m.toggleView(viewDetails), nil
+		case key.Matches(msg, resultsKeys.PrevMetric):
+			if m.view == viewChart {
+				m = m.cycleChartMetric(-1)
+			}
+			return m, nil
+		case key.Matches(msg, resultsKeys.NextMetric):
+			if m.view == viewChart {
+				m = m.cycleChartMetric(1)
 			}
 			return m, nil
-		case "c":
+		case key.Matches(msg, resultsKeys.Column):
+			return m.toggleColumn(int(msg.String()[0] - '0')), nil
+		case key.Matches(msg, resultsKeys.AllColumns):
+			m.hidden = nil
+			m.SaveMsg = ""
+			return m, nil
+		case key.Matches(msg, resultsKeys.Save):
+			// Prompt for a filename, then save in the selected format
+			return m.startSave()
+		case key.Matches(msg, resultsKeys.Format):
+			m = m.cycleSaveFormat()
+			m.SaveMsg = fmt.Sprintf("Save format: %s", m.SaveFormatName())
+			return m, nil
+		case key.Matches(msg, resultsKeys.Copy):
+			// Copy markdown table to the system clipboard
+			if clipboard.Unsupported {
+				m.SaveMsg = "Error: no clipboard available here. Press s to save instead."
+				return m, nil
+			}
+			if err := clipboard.WriteAll(format.GenerateMarkdownTableWithOptions(m.shown(), m.opts)); err != nil {
+				m.SaveMsg = "Error: could not copy to clipboard. Press s to save instead."
+			} else {
+				m.SaveMsg = "Copied markdown table to clipboard!"
+			}
+			return m, nil
+		case key.Matches(msg, resultsKeys.QuickCSV):
 			// Export as CSV
-			content := format.GenerateCSV(m.reports)
+			content := format.GenerateCSVWithOptions(m.shown(), m.opts)
 			filename := "comparison_report.csv"
 			err := os.WriteFile(filename, []byte(content), 0644)
 			if err != nil {
@@ -73,109 +217,302 @@ func (m ResultsModel) View() string {
 	if m.quitting {
 		return ""
 	}
+	if m.showHelp {
+		return helpOverlay("Results Keys", resultsKeys)
+	}
+	switch m.view {
+	case viewChart:
+		return m.chartView() + m.saveView()
+	case viewDetails:
+		return m.detailsView() + m.saveView()
+	case viewDrill:
+		return m.drillView()
+	}
+
+	if len(m.reports) < 2 {
+		return "\n" + resultsTitleStyle.Render(" Comparison Matrix ") + "\n\n" +
+			categoryStyle.Render(fmt.Sprintf("  Need at least two reports to compare (got %d). Use `uxbench summary <file>` for a single recording.", len(m.reports))) + "\n"
+	}
+
+	head, body, _ := m.tableLayout()
+	return strings.Join(append(head, m.scrolled(len(head), body)...), "\n") + "\n" + m.saveView()
+}
 
+// tableCell is one cell of the comparison table.
+type tableCell struct {
+	content string
+	style   lipgloss.Style
+}
+
+// tableGrid builds the comparison table, one slice of cells per line (nil for
+// a blank line), and measures each column. The first headRows lines are the
+// header, task, metadata and spacer rows; rowOf gives each line's metric row
+// index, or -1.
+func (m ResultsModel) tableGrid() (grid [][]tableCell, rowOf []int, headRows int, widths []int) {
 	// 1. Prepare Data Grid (Rows -> Cols)
 	// Row 0: Header (Metric, Prod1, Prod2...)
 	// Row 1: Task (Task, TaskName...)
 	// Row 2: Separator (empty)
 	// Row 3..N: Metrics
 	
-	type cell struct {
-		content string
-		style   lipgloss.Style
-	}
-	
-	var grid [][]cell
-	
+	comparison := format.BuildComparisonGrid(m.shown(), m.opts)
+
 	// Headers
-	headerRow := []cell{{content: "Metric", style: lipgloss.NewStyle()}}
-	for _, r := range m.reports {
-		headerRow = append(headerRow, cell{content: r.Metadata.Product, style: headerStyle})
+	headerRow := []tableCell{{content: "Metric", style: lipgloss.NewStyle()}}
+	for _, p := range comparison.Products {
+		p, _ = fitHeader(p)
+		headerRow = append(headerRow, tableCell{content: p, style: headerStyle})
 	}
+	headerRow = append(headerRow, tableCell{content: "Trend", style: headerStyle})
 	grid = append(grid, headerRow)
+	rowOf = append(rowOf, -1)
 	
 	// Task
-	taskRow := []cell{{content: "Task", style: lipgloss.NewStyle()}}
-	for _, r := range m.reports {
-		taskRow = append(taskRow, cell{content: r.Metadata.Task, style: lipgloss.NewStyle()})
+	taskRow := []tableCell{{content: "Task", style: lipgloss.NewStyle()}}
+	for _, t := range comparison.Tasks {
+		t, _ = fitHeader(t)
+		taskRow = append(taskRow, tableCell{content: t, style: lipgloss.NewStyle()})
 	}
 	grid = append(grid, taskRow)
+	rowOf = append(rowOf, -1)
+
+	// Recording metadata, dimmed to set it apart from the metrics
+	for _, md := range comparison.Metadata {
+		row := []tableCell{{content: md.Label, style: metaStyle}}
+		for _, v := range md.Values {
+			row = append(row, tableCell{content: v, style: metaStyle})
+		}
+		grid = append(grid, row)
+		rowOf = append(rowOf, -1)
+	}
 	
 	// Spacer
 	grid = append(grid, nil) // nil row = spacer
+	rowOf = append(rowOf, -1)
+	headRows = len(grid)
 	
-	// Metric rows from shared registry (core metrics only)
-	for _, def := range format.MetricRegistry {
-		if def.DetailOnly {
-			continue
+	// Metric rows (core metrics only), grouped under category separators
+	rowIndex := 0
+	for _, group := range comparison.Groups {
+		if group.Category != "" {
+			grid = append(grid, []tableCell{{content: "── " + group.Category + " ──", style: categoryStyle}})
+			rowOf = append(rowOf, -1)
 		}
-		row := []cell{{content: def.Label, style: lipgloss.NewStyle()}}
-
-		// Find best
-		bestVal := -1.0
-		first := true
-		for _, r := range m.reports {
-			val := def.Extractor(r.Metrics)
-			if first {
-				bestVal = val
-				first = false
-			} else {
-				if def.HigherIsBetter {
-					if val > bestVal { bestVal = val }
+		for _, gr := range group.Rows {
+			label := gr.Metric.Label + " " + gr.Metric.Arrow()
+			if gr.Metric.Key == m.sortKey {
+				if m.sortReversed {
+					label += " ▴"
 				} else {
-					if val < bestVal { bestVal = val }
+					label += " ▾"
 				}
 			}
-		}
+			labelStyle := lipgloss.NewStyle()
+			if rowIndex == m.cursor {
+				label = "› " + label
+				labelStyle = cursorStyle
+			}
+			rowOf = append(rowOf, rowIndex)
+			rowIndex++
+			row := []tableCell{{content: label, style: labelStyle}}
 
-		for _, r := range m.reports {
-			val := def.Extractor(r.Metrics)
-			valStr := fmt.Sprintf("%.2f", val)
-			style := lipgloss.NewStyle()
+			for i, valStr := range gr.Cells {
+				style := lipgloss.NewStyle()
 
-			if val == bestVal {
-				valStr += "*"
-				style = winnerStyle
+				switch gr.Marks[i] {
+				case format.MarkWinner:
+					valStr += "*"
+					style = winnerStyle
+				case format.MarkTie:
+					valStr += "="
+					style = tieStyle
+				}
+				row = append(row, tableCell{content: valStr, style: style})
 			}
-			row = append(row, cell{content: valStr, style: style})
+			row = append(row, tableCell{content: gr.Trend, style: sparkStyle})
+			grid = append(grid, row)
+		}
+	}
+
+	if m.opts.Footer {
+		grid = append(grid, nil)
+		rowOf = append(rowOf, -1)
+		row := []tableCell{{content: format.FooterLabel, style: headerStyle}}
+		for _, st := range comparison.Standings() {
+			row = append(row, tableCell{content: st.String(), style: headerStyle})
 		}
 		grid = append(grid, row)
+		rowOf = append(rowOf, -1)
 	}
 
 	// 2. Calculate Column Widths
 	// We need to know max visual width for each column index
-	numCols := len(m.reports) + 1
-	colWidths := make([]int, numCols)
+	numCols := len(comparison.Products) + 2 // metric label + products + trend
+	widths = make([]int, numCols)
 	
 	for _, row := range grid {
 		if row == nil { continue }
 		for i, c := range row {
 			w := lipgloss.Width(c.content) // Visual width! ignoring ansi
-			if w > colWidths[i] {
-				colWidths[i] = w
+			if w > widths[i] {
+				widths[i] = w
 			}
 		}
 	}
 	
-	// 3. Render
+	return grid, rowOf, headRows, widths
+}
+
+// tableLayout renders the table view as lines. head (the title and the header,
+// task and metadata rows) stays on screen while body (the metric rows, notes
+// and idle gaps) scrolls; rowLines[i] is the body line of metric row i.
+func (m ResultsModel) tableLayout() (head, body []string, rowLines []int) {
+	grid, rowOf, headRows, widths := m.tableGrid()
+	cols := m.visibleColumns(widths)
+
+	title := resultsTitleStyle.Render(" Comparison Matrix ")
+	if products := len(widths) - 2; len(cols) < len(widths) {
+		title += metaStyle.Render(fmt.Sprintf("  Products %d–%d of %d • ←/→ to scroll", cols[1], min(cols[len(cols)-1], products), products))
+	}
+	head = []string{"", title, ""}
+
+	for i, row := range grid {
+		line := strings.Builder{}
+		for _, c := range cols {
+			if c >= len(row) {
+				break
+			}
+			// Inherit skips padding, so add cellStyle's gap to the width explicitly
+			renderStyle := row[c].style.Copy().Inherit(cellStyle).Width(widths[c] + cellStyle.GetPaddingRight())
+			line.WriteString(renderStyle.Render(row[c].content))
+		}
+		text := line.String()
+		if m.width > 0 {
+			text = ansi.Truncate(text, m.width, "…")
+		}
+		switch {
+		case i < headRows:
+			head = append(head, text)
+		case rowOf[i] >= 0:
+			rowLines = append(rowLines, len(body))
+			fallthrough
+		default:
+			body = append(body, text)
+		}
+	}
+
 	var s strings.Builder
+	if !m.hideLegend {
+		s.WriteString("\n" + metaStyle.Render("  "+format.Legend) + "\n")
+	}
+	comparison := format.BuildComparisonGrid(m.shown(), m.opts)
+	if full := fullNamesLine(comparison.Products, comparison.Tasks); full != "" {
+		s.WriteString("\n" + full + "\n")
+	}
+	if cols := m.columnsLine(); cols != "" {
+		s.WriteString("\n" + cols + "\n")
+	}
+
+	if note := format.FreeTextBurdenNote(m.shown()); note != "" {
+		s.WriteString("\n" + categoryStyle.Render(note) + "\n")
+	}
+	for _, warning := range format.QualityWarnings(m.shown()) {
+		s.WriteString("\n" + categoryStyle.Render(warning) + "\n")
+	}
+
+	// 4. Idle gaps per product
 	s.WriteString("\n")
-	s.WriteString(resultsTitleStyle.Render(" Comparison Matrix "))
-	s.WriteString("\n\n")
-	
-	for _, row := range grid {
-		if row == nil {
-			s.WriteString("\n")
-			continue
+	s.WriteString(resultsTitleStyle.Render(" Idle Gaps "))
+	s.WriteString("\n")
+	labels := format.ProductLabels(m.shown())
+	for i, r := range m.shown() {
+		s.WriteString("\n" + headerStyle.Render(labels[i]) + "\n")
+		s.WriteString(format.IdleGapSection(r))
+	}
+
+	// Wrap here rather than in the terminal so every line is counted when scrolling
+	trailer := strings.TrimSuffix(s.String(), "\n")
+	if m.width > 0 {
+		trailer = ansi.Wrap(trailer, m.width, "")
+	}
+	return head, append(body, strings.Split(trailer, "\n")...), rowLines
+}
+
+// clickAt handles a click on line of the table view: clicking a metric row
+// moves the cursor to it and sorts the products by that metric, best first.
+func (m ResultsModel) clickAt(line int) ResultsModel {
+	if m.view != viewTable || m.showHelp || m.Prompting() || len(m.reports) < 2 {
+		return m
+	}
+	head, body, rowLines := m.tableLayout()
+	line -= len(head)
+	if line < 0 {
+		return m
+	}
+	if off, n, ok := m.bodyWindow(len(head), len(body)); ok {
+		if line >= n {
+			return m
 		}
-		
-		line := strings.Builder{}
-		for i, c := range row {
-			renderStyle := c.style.Copy().Inherit(cellStyle).Width(colWidths[i])
-			line.WriteString(renderStyle.Render(c.content))
+		line += off
+	}
+	rows := format.BuildComparisonGrid(m.shown(), m.opts).Rows()
+	for rowIndex, l := range rowLines {
+		if l == line && rowIndex < len(rows) {
+			row := rows[rowIndex]
+			m.cursor = rowIndex
+			// Clicking the sorted row again flips the order
+			m.sortReversed = row.Metric.Key == m.sortKey && !m.sortReversed
+			m.sortKey = row.Metric.Key
+			return m.sortedBy(row.Metric)
 		}
-		s.WriteString(line.String() + "\n")
 	}
+	return m
+}
+
+// withReports swaps in freshly loaded reports, keeping the clicked sort order.
+func (m ResultsModel) withReports(reports []*schema.BenchmarkReport) ResultsModel {
+	m.reports, m.columns = reports, nil
+	for _, def := range format.MetricRegistry {
+		if def.Key == m.sortKey {
+			m = m.sortedBy(def)
+		}
+	}
+	return m
+}
 
-	return s.String()
+// sortedBy reorders the products by def (see sortOrder), carrying each
+// column's load position along so hidden columns stay hidden.
+func (m ResultsModel) sortedBy(def format.MetricDef) ResultsModel {
+	order := sortOrder(m.reports, def, m.sortReversed)
+	reports, columns := make([]*schema.BenchmarkReport, len(order)), make([]int, len(order))
+	for i, j := range order {
+		reports[i], columns[i] = m.reports[j], m.column(j)
+	}
+	m.reports, m.columns = reports, columns
+	return m
+}
+
+// sortOrder returns the indices of reports ordered best-first by def
+// (worst-first when reversed), missing values last.
+func sortOrder(reports []*schema.BenchmarkReport, def format.MetricDef, reversed bool) []int {
+	if reversed {
+		def.HigherIsBetter = !def.HigherIsBetter
+	}
+	order := make([]int, len(reports))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := def.Value(reports[order[i]]), def.Value(reports[order[j]])
+		switch {
+		case format.IsMissing(a) || format.SameValue(a, b):
+			return false
+		case format.IsMissing(b):
+			return true
+		case def.HigherIsBetter:
+			return a > b
+		}
+		return a < b
+	})
+	return order
 }
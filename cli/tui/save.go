@@ -0,0 +1,138 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+	"uxbench/cli/format"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// saveStage tracks the results view's save prompt.
+type saveStage int
+
+const (
+	saveIdle             saveStage = iota
+	saveEditing                    // editing the target filename
+	saveConfirmOverwrite           // target exists; waiting for y/n
+)
+
+var promptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+
+// defaultSaveName returns a timestamped filename such as comparison_2024-06-01_1530.md.
+func defaultSaveName(ext string) string {
+	return fmt.Sprintf("comparison_%s.%s", time.Now().Format("2006-01-02_1504"), ext)
+}
+
+// Prompting reports whether the save prompt is capturing keystrokes.
+func (m ResultsModel) Prompting() bool {
+	return m.saveStage != saveIdle
+}
+
+func (m ResultsModel) startSave() (ResultsModel, tea.Cmd) {
+	ti := textinput.New()
+	ti.Prompt = "Save as: "
+	ti.CharLimit = 256
+	ti.Width = 60
+	ti.SetValue(defaultSaveName(m.exporter().Ext))
+	ti.CursorEnd()
+	m.saveInput = ti
+	m.saveStage = saveEditing
+	m.SaveMsg = ""
+	return m, m.saveInput.Focus()
+}
+
+func (m ResultsModel) updateSave(msg tea.Msg) (ResultsModel, tea.Cmd) {
+	key, isKey := msg.(tea.KeyMsg)
+
+	switch m.saveStage {
+	case saveConfirmOverwrite:
+		if !isKey {
+			return m, nil
+		}
+		switch key.String() {
+		case "y", "Y":
+			return m.writeSave(), nil
+		case "n", "N", "esc":
+			m.saveStage = saveEditing
+			return m, nil
+		}
+		return m, nil
+
+	case saveEditing:
+		if isKey {
+			switch key.String() {
+			case "esc":
+				m.saveStage = saveIdle
+				m.SaveMsg = "Save cancelled."
+				return m, nil
+			case "enter":
+				path := m.saveInput.Value()
+				if path == "" {
+					return m, nil
+				}
+				if _, err := os.Stat(path); err == nil {
+					m.saveStage = saveConfirmOverwrite
+					return m, nil
+				}
+				return m.writeSave(), nil
+			}
+		}
+		var cmd tea.Cmd
+		m.saveInput, cmd = m.saveInput.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// exporter returns the currently selected save format.
+func (m ResultsModel) exporter() format.Exporter {
+	return format.Exporters[m.saveFormat]
+}
+
+// SaveFormatName is the name of the format 's' will write, for footers.
+func (m ResultsModel) SaveFormatName() string {
+	return m.exporter().Name
+}
+
+// cycleSaveFormat advances to the next export format.
+func (m ResultsModel) cycleSaveFormat() ResultsModel {
+	m.saveFormat = (m.saveFormat + 1) % len(format.Exporters)
+	return m
+}
+
+// writeSave writes the report in the selected format to the prompted path and closes the prompt.
+func (m ResultsModel) writeSave() ResultsModel {
+	path := m.saveInput.Value()
+	m.saveStage = saveIdle
+
+	content := m.exporter().Generate(m.shown(), m.opts)
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			m.SaveMsg = fmt.Sprintf("Error saving: %v", err)
+			return m
+		}
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		m.SaveMsg = fmt.Sprintf("Error saving: %v", err)
+		return m
+	}
+	m.Saved = true
+	m.SaveMsg = fmt.Sprintf("Saved to %s!", path)
+	return m
+}
+
+func (m ResultsModel) saveView() string {
+	switch m.saveStage {
+	case saveEditing:
+		return "\n  " + m.saveInput.View() + "\n  " +
+			lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("(Enter: Save • Esc: Cancel)")
+	case saveConfirmOverwrite:
+		return "\n  " + promptStyle.Render(fmt.Sprintf("%s already exists. Overwrite? (y/n)", m.saveInput.Value()))
+	}
+	return ""
+}
@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// resultsFooterLines is the room left under the results for the compare flow's
+// key hints and messages, or the watch status.
+const resultsFooterLines = 4
+
+// minBodyLines is the fewest body lines worth scrolling; on a shorter
+// terminal the whole view is drawn and the terminal clips it.
+const minBodyLines = 3
+
+// setSize records the terminal size and keeps the cursor row on screen.
+func (m ResultsModel) setSize(width, height int) ResultsModel {
+	m.width, m.height = width, height
+	if m.view == viewDrill {
+		m.drill.Width = width
+		if h := height - drillChrome; h >= 5 {
+			m.drill.Height = h
+		}
+	}
+	return m.scrollToCursor()
+}
+
+// visibleColumns returns the table columns to draw (0 is the metric labels,
+// then one per product, then the trend): all of them when they fit m.width,
+// otherwise the labels and as many products as fit from m.colOffset on.
+func (m ResultsModel) visibleColumns(widths []int) []int {
+	gap := cellStyle.GetPaddingRight()
+	total := 0
+	for _, w := range widths {
+		total += w + gap
+	}
+	cols := []int{0}
+	if m.width <= 0 || total <= m.width {
+		for i := 1; i < len(widths); i++ {
+			cols = append(cols, i)
+		}
+		return cols
+	}
+	used := widths[0] + gap
+	for i := 1 + min(m.colOffset, lastColumnOffset(widths, m.width)); i < len(widths); i++ {
+		// Always show one product, even if the line then gets cut off
+		if used+widths[i]+gap > m.width && len(cols) > 1 {
+			break
+		}
+		cols = append(cols, i)
+		used += widths[i] + gap
+	}
+	return cols
+}
+
+// lastColumnOffset is the largest useful colOffset: the one that brings the
+// last products (and the trend, if it fits) into view.
+func lastColumnOffset(widths []int, width int) int {
+	gap := cellStyle.GetPaddingRight()
+	used, first := widths[0]+gap, len(widths)-1
+	for i := len(widths) - 1; i >= 1; i-- {
+		if used += widths[i] + gap; used > width {
+			break
+		}
+		first = i
+	}
+	return max(0, min(first, len(widths)-2)-1)
+}
+
+// scrollColumns moves the product columns shown on a narrow terminal by delta.
+func (m ResultsModel) scrollColumns(delta int) ResultsModel {
+	if m.width <= 0 || len(m.reports) < 2 {
+		return m
+	}
+	_, _, _, widths := m.tableGrid()
+	last := lastColumnOffset(widths, m.width)
+	m.colOffset = max(0, min(min(m.colOffset, last)+delta, last))
+	return m
+}
+
+// bodyHeight is how many body lines fit under headLines of table head,
+// leaving room for the save prompt and the footer.
+func (m ResultsModel) bodyHeight(headLines int) int {
+	h := m.height - headLines - resultsFooterLines
+	if save := m.saveView(); save != "" {
+		h -= strings.Count(save, "\n") + 1
+	}
+	return h
+}
+
+// bodyWindow returns the first body line shown and how many are shown, or
+// ok=false when the whole body fits (or the terminal size is unknown). One
+// line below the window is kept for the scroll position.
+func (m ResultsModel) bodyWindow(headLines, bodyLines int) (off, n int, ok bool) {
+	if m.height <= 0 {
+		return 0, 0, false
+	}
+	h := m.bodyHeight(headLines)
+	if bodyLines <= h || h-1 < minBodyLines {
+		return 0, 0, false
+	}
+	n = h - 1
+	return max(0, min(m.offset, bodyLines-n)), n, true
+}
+
+// scrolled returns the body lines that fit the terminal, followed by the
+// scroll position, or all of body when it fits.
+func (m ResultsModel) scrolled(headLines int, body []string) []string {
+	off, n, ok := m.bodyWindow(headLines, len(body))
+	if !ok {
+		return body
+	}
+	out := append([]string(nil), body[off:off+n]...)
+	return append(out, metaStyle.Render(fmt.Sprintf("  Lines %d–%d of %d • PgUp/PgDn to scroll", off+1, off+n, len(body))))
+}
+
+// scrollToCursor scrolls the body just enough to show the cursor row.
+func (m ResultsModel) scrollToCursor() ResultsModel {
+	if m.height <= 0 || len(m.reports) < 2 {
+		return m
+	}
+	head, body, rowLines := m.tableLayout()
+	off, n, ok := m.bodyWindow(len(head), len(body))
+	if !ok || m.cursor >= len(rowLines) {
+		return m
+	}
+	switch line := rowLines[m.cursor]; {
+	case line < off:
+		off = max(0, line-1) // keep a category heading above the row in view
+	case line >= off+n:
+		off = line - n + 1
+	}
+	m.offset = off
+	return m
+}
+
+// scrollPage scrolls the body a page up (dir -1) or down (dir 1).
+func (m ResultsModel) scrollPage(dir int) ResultsModel {
+	if len(m.reports) < 2 {
+		return m
+	}
+	head, body, _ := m.tableLayout()
+	off, n, ok := m.bodyWindow(len(head), len(body))
+	if !ok {
+		return m
+	}
+	m.offset = max(0, min(off+dir*n, len(body)-n))
+	return m
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// pickerState is persisted between runs so the picker reopens where the user left off.
+type pickerState struct {
+	LastDir string `json:"last_dir"`
+}
+
+func stateFilePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "uxbench", "state.json"), nil
+}
+
+// PickerRoot, when set (from the config file), is where the picker opens
+// instead of the last-used directory.
+var PickerRoot string
+
+// loadLastDir returns the saved picker directory if it still exists, otherwise "".
+func loadLastDir() string {
+	path, err := stateFilePath()
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	var st pickerState
+	if err := json.Unmarshal(data, &st); err != nil || st.LastDir == "" {
+		return ""
+	}
+	if info, err := os.Stat(st.LastDir); err != nil || !info.IsDir() {
+		return ""
+	}
+	return st.LastDir
+}
+
+// saveLastDir records dir as the picker's starting point for the next run.
+// Failures are ignored: losing the last directory is not worth interrupting the user.
+func saveLastDir(dir string) {
+	path, err := stateFilePath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	data, err := json.MarshalIndent(pickerState{LastDir: dir}, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0644)
+}
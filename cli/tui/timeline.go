@@ -0,0 +1,210 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"uxbench/schema"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	timeStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Width(10).Align(lipgloss.Right)
+	actionStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true).Width(12)
+	gapStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+)
+
+// TimelineModel renders a single report's action log as a scrollable vertical timeline.
+type TimelineModel struct {
+	report   *schema.BenchmarkReport
+	total    int      // entries in the full action log; more than len(report.ActionLog) when capped
+	types    []string // distinct action types, in first-seen order
+	filter   string   // "" = all types
+	viewport viewport.Model
+	ready    bool
+	quitting bool
+}
+
+// NewTimelineModel creates a timeline for report, optionally limited to one action type.
+// total is the length of the report's full action log, of which report may
+// hold only the first entries (see loader.LoadOptions.MaxActions).
+func NewTimelineModel(report *schema.BenchmarkReport, total int, filter string) TimelineModel {
+	seen := make(map[string]bool)
+	var types []string
+	for _, e := range report.ActionLog {
+		if !seen[e.Type] {
+			seen[e.Type] = true
+			types = append(types, e.Type)
+		}
+	}
+	return TimelineModel{report: report, total: max(total, len(report.ActionLog)), types: types, filter: filter}
+}
+
+func (m TimelineModel) Init() tea.Cmd { return nil }
+
+func (m TimelineModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// Reserve space for header/footer
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, msg.Height-6)
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = msg.Height - 6
+		}
+		m.viewport.SetContent(m.renderEntries())
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			m.quitting = true
+			return m, tea.Quit
+		case "f":
+			// Cycle filter: all -> type1 -> type2 -> ... -> all
+			m.filter = m.nextFilter()
+			m.viewport.SetContent(m.renderEntries())
+			m.viewport.GotoTop()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m TimelineModel) nextFilter() string {
+	if len(m.types) == 0 {
+		return ""
+	}
+	if m.filter == "" {
+		return m.types[0]
+	}
+	for i, t := range m.types {
+		if t == m.filter && i+1 < len(m.types) {
+			return m.types[i+1]
+		}
+	}
+	return ""
+}
+
+// timelineGap pairs an idle gap with the action log index it precedes.
+type timelineGap struct {
+	gap    schema.IdleGap
+	before int
+}
+
+// placeGaps matches recorded idle gaps to the interval between action log entries
+// where they occurred. Gaps are recorded chronologically, so each gap is placed in the
+// first unused interval long enough to contain it. Gaps that fit nowhere get before = -1.
+func placeGaps(log []schema.ActionLogEntry, gaps []schema.IdleGap) []timelineGap {
+	placed := make([]timelineGap, 0, len(gaps))
+	next := 1
+	for _, g := range gaps {
+		tg := timelineGap{gap: g, before: -1}
+		for i := next; i < len(log); i++ {
+			if log[i].Timestamp-log[i-1].Timestamp >= g.GapMS {
+				tg.before = i
+				next = i + 1
+				break
+			}
+		}
+		placed = append(placed, tg)
+	}
+	return placed
+}
+
+func formatOffset(ms float64) string {
+	if ms < 0 {
+		ms = 0
+	}
+	total := int(ms)
+	return fmt.Sprintf("+%d:%02d.%03d", total/60000, (total/1000)%60, total%1000)
+}
+
+func (m TimelineModel) renderEntries() string {
+	log := m.report.ActionLog
+	if len(log) == 0 {
+		return "  (This report has no action log.)"
+	}
+
+	sorted := make([]schema.ActionLogEntry, len(log))
+	copy(sorted, log)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Timestamp < sorted[j].Timestamp })
+
+	gapsBefore := make(map[int][]schema.IdleGap)
+	var unplaced []schema.IdleGap
+	for _, tg := range placeGaps(sorted, m.report.Metrics.TimeOnTask.IdleGaps) {
+		if tg.before < 0 {
+			unplaced = append(unplaced, tg.gap)
+			continue
+		}
+		gapsBefore[tg.before] = append(gapsBefore[tg.before], tg.gap)
+	}
+
+	start := sorted[0].Timestamp
+	var s strings.Builder
+	shown := 0
+	for i, e := range sorted {
+		for _, g := range gapsBefore[i] {
+			s.WriteString(renderGap(g) + "\n")
+		}
+		if m.filter != "" && e.Type != m.filter {
+			continue
+		}
+		target := e.Target
+		if e.Text != "" {
+			target = fmt.Sprintf("%s %q", target, e.Text)
+		}
+		s.WriteString(fmt.Sprintf("%s  │ %s %s\n",
+			timeStyle.Render(formatOffset(e.Timestamp-start)),
+			actionStyle.Render(e.Type),
+			target,
+		))
+		shown++
+	}
+	if shown == 0 {
+		s.WriteString(fmt.Sprintf("  (No %q actions in this report)\n", m.filter))
+	}
+
+	if len(unplaced) > 0 {
+		s.WriteString("\n  Idle gaps outside the logged actions:\n")
+		for _, g := range unplaced {
+			s.WriteString(renderGap(g) + "\n")
+		}
+	}
+	return s.String()
+}
+
+func renderGap(g schema.IdleGap) string {
+	return timeStyle.Render("") + "  " + gapStyle.Render(fmt.Sprintf("┆ ⏸ %.1fs idle (after %q, before %q)", g.GapMS/1000, g.AfterAction, g.BeforeAction))
+}
+
+func (m TimelineModel) View() string {
+	if m.quitting {
+		return ""
+	}
+	if !m.ready {
+		return "\n  Loading timeline..."
+	}
+
+	filter := "all"
+	if m.filter != "" {
+		filter = m.filter
+	}
+	title := resultsTitleStyle.Render(fmt.Sprintf(" Timeline: %s — %s ", m.report.Metadata.Product, m.report.Metadata.Task))
+	count := fmt.Sprintf("%d actions", len(m.report.ActionLog))
+	if m.total > len(m.report.ActionLog) {
+		count = fmt.Sprintf("first %d of %d actions", len(m.report.ActionLog), m.total)
+	}
+	info := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(
+		fmt.Sprintf("  %s • filter: %s • %3.f%%", count, filter, m.viewport.ScrollPercent()*100))
+	footer := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("\n  (↑/↓: Scroll • f: Filter Type • q: Quit)")
+
+	return "\n" + title + "\n" + info + "\n\n" + m.viewport.View() + footer
+}
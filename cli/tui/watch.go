@@ -0,0 +1,169 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+	"uxbench/cli/format"
+	"uxbench/cli/loader"
+	"uxbench/schema"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce coalesces the burst of events a single save produces.
+const watchDebounce = 300 * time.Millisecond
+
+var watchInfoStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+// WatchModel shows comparison results and reloads them whenever one of the
+// compared files changes on disk.
+type WatchModel struct {
+	results   ResultsModel
+	paths     []string
+	watched   map[string]bool // cleaned paths of the compared files
+	watcher   *fsnotify.Watcher
+	seq       int // bumped per change; only the latest debounce tick reloads
+	refreshed time.Time
+	warning   string
+}
+
+type fileChangedMsg struct{}
+type watchErrMsg struct{ err error }
+type reloadTickMsg struct{ seq int }
+type reloadedMsg struct {
+	reports []*schema.BenchmarkReport
+	errs    []error
+}
+
+// NewWatchModel starts watching paths, whose already-loaded reports are shown
+// until the first change. The caller must Close the model when the program exits.
+func NewWatchModel(paths []string, reports []*schema.BenchmarkReport, opts format.Options) (WatchModel, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return WatchModel{}, err
+	}
+
+	// Watch directories rather than files: editors and recorders often save by
+	// writing a new file and renaming it over the old one.
+	watched := make(map[string]bool)
+	dirs := make(map[string]bool)
+	for _, p := range paths {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			w.Close()
+			return WatchModel{}, err
+		}
+		watched[abs] = true
+		dirs[filepath.Dir(abs)] = true
+	}
+	for d := range dirs {
+		if err := w.Add(d); err != nil {
+			w.Close()
+			return WatchModel{}, fmt.Errorf("failed to watch %s: %w", d, err)
+		}
+	}
+
+	return WatchModel{
+		results:   NewResultsModelWithOptions(reports, opts),
+		paths:     paths,
+		watched:   watched,
+		watcher:   w,
+		refreshed: time.Now(),
+	}, nil
+}
+
+// Close stops the filesystem watcher.
+func (m WatchModel) Close() error {
+	return m.watcher.Close()
+}
+
+func (m WatchModel) Init() tea.Cmd {
+	return m.waitForChange()
+}
+
+// waitForChange blocks until a compared file changes.
+func (m WatchModel) waitForChange() tea.Cmd {
+	return func() tea.Msg {
+		for {
+			select {
+			case ev, ok := <-m.watcher.Events:
+				if !ok {
+					return nil
+				}
+				if m.watched[filepath.Clean(ev.Name)] && ev.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+					return fileChangedMsg{}
+				}
+			case err, ok := <-m.watcher.Errors:
+				if !ok {
+					return nil
+				}
+				return watchErrMsg{err}
+			}
+		}
+	}
+}
+
+func (m WatchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case fileChangedMsg:
+		m.seq++
+		seq := m.seq
+		return m, tea.Batch(
+			m.waitForChange(),
+			tea.Tick(watchDebounce, func(time.Time) tea.Msg { return reloadTickMsg{seq} }),
+		)
+
+	case watchErrMsg:
+		m.warning = fmt.Sprintf("Watch error: %v", msg.err)
+		return m, m.waitForChange()
+
+	case reloadTickMsg:
+		if msg.seq != m.seq {
+			return m, nil // superseded by a later change
+		}
+		paths := m.paths
+		return m, func() tea.Msg {
+			reports, errs := loader.LoadReports(paths)
+			return reloadedMsg{reports, errs}
+		}
+
+	case reloadedMsg:
+		for i, err := range msg.errs {
+			if err != nil {
+				// Keep the last good results; the file may be mid-write
+				m.warning = fmt.Sprintf("Reload failed for %s: %v", filepath.Base(m.paths[i]), err)
+				return m, nil
+			}
+		}
+		m.results = m.results.withReports(format.GroupReports(msg.reports, m.results.opts.GroupBy))
+		m.refreshed = time.Now()
+		m.warning = ""
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.MouseMsg); ok && !m.results.ShowingDrillDown() {
+		// Map rows against the whole frame, which includes the watch status below the results
+		if isClick(msg) {
+			m.results = m.results.clickAt(frameLine(msg.Y, m.View(), m.results.height))
+		}
+		return m, nil
+	}
+
+	newResults, cmd := m.results.Update(msg)
+	m.results = newResults.(ResultsModel)
+	return m, cmd
+}
+
+func (m WatchModel) View() string {
+	if m.results.quitting {
+		return ""
+	}
+	status := "\n  " + watchInfoStyle.Render(fmt.Sprintf("Watching %d files • refreshed at %s", len(m.paths), m.refreshed.Format("15:04:05")))
+	if m.warning != "" {
+		status += "\n  " + loadErrStyle.Render(m.warning)
+	}
+	return m.results.View() + status
+}
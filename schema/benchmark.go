@@ -1,24 +1,28 @@
 package schema
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
-// BenchmarkReport matches the JSON schema structure
+// BenchmarkReport matches the JSON schema structure. Fields tagged
+// jsonschema:"required" are the ones `uxbench schema` marks required.
 type BenchmarkReport struct {
-	SchemaVersion string          `json:"schema_version"`
-	Source        string          `json:"source"`
-	Metadata      BenchmarkMetadata `json:"metadata"`
-	Metrics       BenchmarkMetrics  `json:"metrics"`
+	SchemaVersion string          `json:"schema_version" jsonschema:"required"`
+	Source        string          `json:"source" jsonschema:"required"`
+	Metadata      BenchmarkMetadata `json:"metadata" jsonschema:"required"`
+	Metrics       BenchmarkMetrics  `json:"metrics" jsonschema:"required"`
 	HumanSignals  *HumanSignals     `json:"human_signals,omitempty"`
 	ActionLog     []ActionLogEntry  `json:"action_log,omitempty"`
 }
 
 type BenchmarkMetadata struct {
 	RecordingName   string    `json:"recording_name"`
-	Product         string    `json:"product"`
+	Product         string    `json:"product" jsonschema:"required"`
 	Task            string    `json:"task"`
 	URL             string    `json:"url"`
 	URLsVisited     []string  `json:"urls_visited"`
-	Timestamp       time.Time `json:"timestamp"`
+	Timestamp       time.Time `json:"timestamp" jsonschema:"required"`
 	DurationMS      int       `json:"duration_ms"`
 	Browser         string    `json:"browser"`
 	SourceVersion   string    `json:"source_version"`
@@ -38,7 +42,7 @@ type BenchmarkMetrics struct {
 	TypingRatio      TypingRatio      `json:"typing_ratio"`
 	ScanningDistance ScanningDistance `json:"scanning_distance"`
 	ScrollDistance   ScrollDistance   `json:"scroll_distance"`
-	CompositeScore   float64          `json:"composite_score"`
+	CompositeScore   float64          `json:"composite_score" jsonschema:"required"`
 }
 
 type ClickCount struct {
@@ -164,4 +168,87 @@ type ThroughputIndex struct {
 	ComparisonToNorm     string  `json:"comparison_to_norm"`
 }
 
-type ActionLogEntry map[string]interface{}
+// ActionLogEntry is a single entry of the optional per-action research log.
+// Known fields are decoded into typed members; any other keys (or known keys
+// carrying an unexpected type) are kept in Extra so older and newer recorder
+// output still loads and round-trips unchanged.
+type ActionLogEntry struct {
+	Type           string                 `json:"type"`
+	Timestamp      float64                `json:"timestamp"` // epoch ms
+	Target         string                 `json:"target,omitempty"`
+	Text           string                 `json:"text,omitempty"`
+	Classification string                 `json:"classification,omitempty"`
+	X              *float64               `json:"x,omitempty"`
+	Y              *float64               `json:"y,omitempty"`
+	Key            string                 `json:"key,omitempty"`
+	Extra          map[string]interface{} `json:"-"`
+}
+
+// Time returns the entry timestamp as a time.Time.
+func (e ActionLogEntry) Time() time.Time {
+	return time.UnixMilli(int64(e.Timestamp))
+}
+
+func (e *ActionLogEntry) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*e = ActionLogEntry{}
+	fields := map[string]interface{}{
+		"type":           &e.Type,
+		"timestamp":      &e.Timestamp,
+		"target":         &e.Target,
+		"text":           &e.Text,
+		"classification": &e.Classification,
+		"x":              &e.X,
+		"y":              &e.Y,
+		"key":            &e.Key,
+	}
+
+	for k, v := range raw {
+		if dst, ok := fields[k]; ok {
+			if err := json.Unmarshal(v, dst); err == nil {
+				continue
+			}
+		}
+		var generic interface{}
+		if err := json.Unmarshal(v, &generic); err != nil {
+			return err
+		}
+		if e.Extra == nil {
+			e.Extra = make(map[string]interface{})
+		}
+		e.Extra[k] = generic
+	}
+	return nil
+}
+
+func (e ActionLogEntry) MarshalJSON() ([]byte, error) {
+	out := make(map[string]interface{}, len(e.Extra)+8)
+	for k, v := range e.Extra {
+		out[k] = v
+	}
+	out["type"] = e.Type
+	out["timestamp"] = e.Timestamp
+	if e.Target != "" {
+		out["target"] = e.Target
+	}
+	if e.Text != "" {
+		out["text"] = e.Text
+	}
+	if e.Classification != "" {
+		out["classification"] = e.Classification
+	}
+	if e.X != nil {
+		out["x"] = *e.X
+	}
+	if e.Y != nil {
+		out["y"] = *e.Y
+	}
+	if e.Key != "" {
+		out["key"] = e.Key
+	}
+	return json.Marshal(out)
+}
@@ -0,0 +1,76 @@
+package schema
+
+import "fmt"
+
+// CurrentSchemaVersion is the report schema version these types describe.
+const CurrentSchemaVersion = "1.0"
+
+// Thresholds for Quality.
+const (
+	shortRecordingMS = 10000 // recordings under 10s rarely capture a whole task
+	maxOptionalLoss  = 0.2   // cap on the deduction for missing optional metrics
+)
+
+// Quality rates how far a report's numbers can be trusted, from 1 (no
+// concerns) down to 0, with one reason per deduction: a very short or
+// missing duration, no clicks, missing optional metrics, or a schema version
+// other than CurrentSchemaVersion.
+func (r *BenchmarkReport) Quality() (float64, []string) {
+	score := 1.0
+	var reasons []string
+
+	duration := r.Metadata.DurationMS
+	if duration == 0 {
+		duration = r.Metrics.TimeOnTask.TotalMS
+	}
+	switch {
+	case duration <= 0:
+		score -= 0.3
+		reasons = append(reasons, "no duration recorded")
+	case duration < shortRecordingMS:
+		score -= 0.4
+		reasons = append(reasons, fmt.Sprintf("short recording (%.1fs)", float64(duration)/1000))
+	}
+
+	if r.Metrics.ClickCount.Total == 0 {
+		score -= 0.3
+		reasons = append(reasons, "no clicks recorded")
+	}
+
+	if missing := r.missingOptionalMetrics(); missing > 0 {
+		score -= min(0.05*float64(missing), maxOptionalLoss)
+		reasons = append(reasons, fmt.Sprintf("%d optional metric(s) missing", missing))
+	}
+
+	switch r.SchemaVersion {
+	case CurrentSchemaVersion:
+	case "":
+		score -= 0.1
+		reasons = append(reasons, "no schema version")
+	default:
+		score -= 0.1
+		reasons = append(reasons, fmt.Sprintf("schema version %s (expected %s)", r.SchemaVersion, CurrentSchemaVersion))
+	}
+
+	return max(score, 0), reasons
+}
+
+// missingOptionalMetrics counts the nil optional metric fields, which older
+// recorders and partial recordings leave out.
+func (r *BenchmarkReport) missingOptionalMetrics() int {
+	m := r.Metrics
+	n := 0
+	for _, present := range []bool{
+		m.TimeOnTask.ActiveMS != nil,
+		m.TimeOnTask.IdleMS != nil,
+		m.Fitts.Throughput != nil,
+		m.Fitts.AveragePathEfficiency != nil,
+		m.Fitts.TotalOvershoots != nil,
+		m.ScrollDistance.ScrollEvents != nil,
+	} {
+		if !present {
+			n++
+		}
+	}
+	return n
+}